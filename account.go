@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+)
+
+var (
+	ErrInvalidAmount     = errors.New("amount must be a positive number")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+)
+
+// Account holds a balance and knows how to persist it.
+// Safe for concurrent use 🏦
+type Account struct {
+	mu      sync.Mutex
+	balance float64
+	tier    AccountTier                                      // decides the interest strategy
+	policy  withdrawalPolicy                                 // fee & minimum balance for withdrawals
+	persist func(ctx context.Context, balance float64) error // nil = in-memory only
+}
+
+// NewAccount starts an account under bankWithdrawalPolicy.
+func NewAccount(balance float64, persist func(ctx context.Context, balance float64) error) *Account {
+	return &Account{balance: balance, policy: bankWithdrawalPolicy, persist: persist}
+}
+
+func (a *Account) Balance() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.balance
+}
+
+func (a *Account) Tier() AccountTier {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.tier
+}
+
+func (a *Account) SetTier(t AccountTier) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.tier = t
+}
+
+func (a *Account) WithdrawalPolicy() withdrawalPolicy {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.policy
+}
+
+func (a *Account) SetWithdrawalPolicy(p withdrawalPolicy) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.policy = p
+}
+
+// Deposit adds amount to acc. The balance only changes if persisting succeeded.
+func Deposit(ctx context.Context, acc *Account, amount float64) error {
+	return acc.operate(ctx, "deposit", amount, amount)
+}
+
+// Withdraw takes amount plus the policy's fee from acc, failing with
+// ErrInsufficientFunds if that would leave less than the policy's minimum balance.
+// The balance only changes if persisting succeeded.
+func Withdraw(ctx context.Context, acc *Account, amount float64) error {
+	return acc.operate(ctx, "withdraw", amount, -amount)
+}
+
+// operate validates amount, applies delta and logs each step with the ctx's trace id.
+func (a *Account) operate(ctx context.Context, op string, amount, delta float64) error {
+	log := loggerFrom(ctx).With("op", op, "amount", amount)
+	log.Info("operation started")
+
+	err := validateAmount(amount)
+	if err == nil {
+		err = a.apply(ctx, delta)
+	}
+	if err != nil {
+		log.Warn("operation failed", "error", err)
+		return err
+	}
+	log.Info("operation completed", "balance", a.Balance())
+	return nil
+}
+
+func validateAmount(amount float64) error {
+	if amount <= 0 || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return fmt.Errorf("%w: %v", ErrInvalidAmount, amount)
+	}
+	return nil
+}
+
+func (a *Account) apply(ctx context.Context, delta float64) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.applyLocked(ctx, delta)
+}
+
+// applyLocked is apply for callers already holding a.mu.
+// Withdrawals (delta < 0) also pay a.policy's Fee and must leave its MinBalance behind.
+func (a *Account) applyLocked(ctx context.Context, delta float64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	floor := 0.0
+	if delta < 0 {
+		delta -= a.policy.Fee
+		floor = a.policy.MinBalance
+	}
+	// balances are whole cents; rounding drops float noise (128.01 - 28.01 = 99.99999999999999)
+	newBalance := RoundMoney(a.balance+delta, HalfEven)
+	if newBalance < floor {
+		return fmt.Errorf("%w: balance %.2f, requested %.2f", ErrInsufficientFunds, a.balance, -delta)
+	}
+	if a.persist != nil {
+		if err := a.persist(ctx, newBalance); err != nil {
+			return fmt.Errorf("saving balance: %w", err)
+		}
+		loggerFrom(ctx).Info("balance persisted", "balance", newBalance)
+	}
+	a.balance = newBalance
+	return nil
+}
+
+// debouncedPersist adapts a coalescing update func (see CoalescingWriter) to a
+// persist func. A cancelled ctx is reported before anything is queued, so the
+// balance never changes for an operation that was already abandoned; so is an
+// earlier background save that failed.
+func debouncedPersist(update func(float64) error) func(ctx context.Context, balance float64) error {
+	return func(ctx context.Context, balance float64) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return update(balance)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestDepositWithdraw(t *testing.T) {
+	ctx := context.Background()
+	acc := NewAccount(100, nil)
+
+	if err := Deposit(ctx, acc, 50); err != nil {
+		t.Fatalf("Deposit: %v", err)
+	}
+	if err := Withdraw(ctx, acc, 30); err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	if got := acc.Balance(); got != 120 {
+		t.Errorf("balance = %v, want 120", got)
+	}
+}
+
+func TestValidationErrors(t *testing.T) {
+	ctx := context.Background()
+	acc := NewAccount(100, nil)
+
+	for _, amount := range []float64{0, -5, math.NaN(), math.Inf(1)} {
+		if err := Deposit(ctx, acc, amount); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("Deposit(%v) err = %v, want ErrInvalidAmount", amount, err)
+		}
+		if err := Withdraw(ctx, acc, amount); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("Withdraw(%v) err = %v, want ErrInvalidAmount", amount, err)
+		}
+	}
+	if err := Withdraw(ctx, acc, 100.01); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("overdraw err = %v, want ErrInsufficientFunds", err)
+	}
+	if got := acc.Balance(); got != 100 {
+		t.Errorf("balance = %v after rejected operations, want 100", got)
+	}
+}
+
+func TestCancelledBeforeOperation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	persisted := false
+	acc := NewAccount(100, func(context.Context, float64) error {
+		persisted = true
+		return nil
+	})
+	if err := Deposit(ctx, acc, 10); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if persisted || acc.Balance() != 100 {
+		t.Errorf("persisted = %v, balance = %v; want nothing to happen", persisted, acc.Balance())
+	}
+}
+
+func TestCancelDuringPersistence(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	acc := NewAccount(100, func(ctx context.Context, _ float64) error {
+		close(started)
+		<-ctx.Done() // a slow disk, abandoned by the caller
+		return ctx.Err()
+	})
+
+	errc := make(chan error, 1)
+	go func() { errc <- Deposit(ctx, acc, 10) }()
+	<-started
+	cancel()
+
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if got := acc.Balance(); got != 100 {
+		t.Errorf("balance = %v, want 100 (persist was cancelled)", got)
+	}
+}
+
+func TestDebouncedPersist(t *testing.T) {
+	var mu sync.Mutex
+	var saved []float64
+	update, flush := CoalescingWriter(func(b float64) error {
+		mu.Lock()
+		defer mu.Unlock()
+		saved = append(saved, b)
+		return nil
+	}, time.Hour)
+	acc := NewAccount(100, debouncedPersist(update))
+
+	ctx := context.Background()
+	Deposit(ctx, acc, 10)
+	Withdraw(ctx, acc, 5)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := Deposit(cancelled, acc, 1000); !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled Deposit err = %v, want context.Canceled", err)
+	}
+
+	if err := flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(saved) != 1 || saved[0] != 105 {
+		t.Errorf("saved %v, want one save of 105", saved)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// currencySymbols that ParseAmount strips off the input
+const currencySymbols = "$₹€£¥"
+
+// ParseAmount parses what a user types for an amount - "50", "$50.00",
+// "₹1,200.50" - into MINOR units (cents/paise), ex: "$50.25" -> 5025.
+// At most 2 decimals are allowed, and thousands separators must group by 3.
+func ParseAmount(s string) (int64, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || strings.ContainsRune(currencySymbols, r) {
+			return -1 // drop it
+		}
+		return r
+	}, s)
+	if cleaned == "" {
+		return 0, errors.New("empty amount")
+	}
+
+	whole, frac, hasDot := strings.Cut(cleaned, ".")
+	if hasDot && (len(frac) == 0 || len(frac) > 2) {
+		return 0, fmt.Errorf("invalid amount %q: use at most 2 decimal places", s)
+	}
+	whole, err := stripThousands(whole)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+	if whole == "" {
+		whole = "0" // ".50"
+	}
+	frac += strings.Repeat("0", 2-len(frac)) // "5" -> "50"
+
+	var minor int64
+	for _, r := range whole + frac {
+		if r < '0' || r > '9' {
+			return 0, fmt.Errorf("invalid amount %q", s)
+		}
+		digit := int64(r - '0')
+		if minor > (math.MaxInt64-digit)/10 {
+			return 0, fmt.Errorf("amount %q is too large", s)
+		}
+		minor = minor*10 + digit
+	}
+	return minor, nil
+}
+
+// stripThousands removes "," separators after checking they group digits by 3
+func stripThousands(whole string) (string, error) {
+	if !strings.Contains(whole, ",") {
+		return whole, nil
+	}
+	groups := strings.Split(whole, ",")
+	if len(groups[0]) == 0 || len(groups[0]) > 3 {
+		return "", errors.New("misplaced thousands separator")
+	}
+	for _, g := range groups[1:] {
+		if len(g) != 3 {
+			return "", errors.New("misplaced thousands separator")
+		}
+	}
+	return strings.Join(groups, ""), nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import "testing"
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+	}{
+		{"50", 5000},
+		{"$50.00", 5000},
+		{"₹1,200.50", 120050},
+		{" € 7.5 ", 750},
+		{".99", 99},
+		{"1,000,000", 100000000},
+		{"£0.01", 1},
+	}
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.in)
+		if err != nil {
+			t.Errorf("ParseAmount(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAmount(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseAmountInvalid(t *testing.T) {
+	for _, in := range []string{
+		"", "  ", "$", "abc", "12abc", "-5", "1.234", "5.",
+		"1,00", ",100", "1234,567", "1..2", "99999999999999999999",
+	} {
+		if got, err := ParseAmount(in); err == nil {
+			t.Errorf("ParseAmount(%q) = %d, want an error", in, got)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "balance.txt")
+
+	for _, content := range []string{"1000.00", "25.50"} {
+		if err := WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("WriteFile(%q): %v", content, err)
+		}
+		got, err := os.ReadFile(path)
+		if err != nil || string(got) != content {
+			t.Fatalf("file = %q, %v; want %q", got, err, content)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d entries, want only the target (no temp files)", len(entries))
+	}
+}
+
+func TestWriteFileMissingDir(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nope", "balance.txt")
+	if err := WriteFile(path, []byte("x"), 0644); err == nil {
+		t.Error("WriteFile into a missing directory should fail")
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// BackoffWithJitter returns how long to wait before retry number attempt (0-based):
+// exponential backoff base*2^attempt capped at max, with FULL jitter - a random
+// duration in [0, cap] - so many clients retrying together don't stampede 🐘🐘🐘
+func BackoffWithJitter(attempt int, base, max time.Duration) time.Duration {
+	if base <= 0 || max <= 0 {
+		return 0
+	}
+	ceiling := base
+	for i := 0; i < attempt && ceiling < max; i++ {
+		if ceiling > max/2 { // doubling would pass max (or overflow)
+			ceiling = max
+			break
+		}
+		ceiling *= 2
+	}
+	ceiling = min(ceiling, max)
+	if ceiling == math.MaxInt64 {
+		return time.Duration(rand.Int64()) // already [0, MaxInt64]; ceiling+1 would overflow
+	}
+	return time.Duration(rand.Int64N(int64(ceiling) + 1))
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBackoffWithJitterBounds(t *testing.T) {
+	base, max := 100*time.Millisecond, 2*time.Second
+	for attempt := 0; attempt < 10; attempt++ {
+		ceiling := min(max, base*time.Duration(1<<attempt))
+		for i := 0; i < 200; i++ {
+			d := BackoffWithJitter(attempt, base, max)
+			if d < 0 || d > ceiling {
+				t.Fatalf("attempt %d: %v outside [0, %v]", attempt, d, ceiling)
+			}
+		}
+	}
+}
+
+func TestBackoffWithJitterExtremes(t *testing.T) {
+	if d := BackoffWithJitter(3, 0, time.Second); d != 0 {
+		t.Errorf("zero base = %v, want 0", d)
+	}
+	if d := BackoffWithJitter(3, time.Second, 0); d != 0 {
+		t.Errorf("zero max = %v, want 0", d)
+	}
+
+	huge := time.Duration(math.MaxInt64)
+	tests := []struct {
+		attempt   int
+		base, max time.Duration
+	}{
+		{0, huge, huge},              // ceiling+1 would overflow
+		{5, huge / 3, huge},          // doubling would overflow
+		{1000, time.Second, huge},    // many attempts
+		{62, 1, huge},                // 2^62 still fits, 2^63 wouldn't
+		{10, time.Hour, time.Second}, // base above max
+	}
+	for _, tt := range tests {
+		for i := 0; i < 50; i++ {
+			d := BackoffWithJitter(tt.attempt, tt.base, tt.max)
+			if d < 0 || d > tt.max {
+				t.Fatalf("BackoffWithJitter(%d, %v, %v) = %v, outside [0, max]", tt.attempt, tt.base, tt.max, d)
+			}
+		}
+	}
+}
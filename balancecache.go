@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"example.com/bank/atomicfile"
+)
+
+var ErrInvalidAccountName = errors.New("invalid account name")
+
+// BalanceCache is a read-through cache for per-account balance files
+// (<dir>/<account>.txt). Reads hit the disk only on a miss, writes go to disk
+// and invalidate the cached entry. Safe for concurrent use 🗄️
+type BalanceCache struct {
+	dir string
+
+	mu     sync.Mutex
+	cache  *lruCache[string, float64]
+	hits   int
+	misses int
+}
+
+func NewBalanceCache(dir string, capacity int) *BalanceCache {
+	return &BalanceCache{dir: dir, cache: newLRUCache[string, float64](capacity)}
+}
+
+// path maps account to its balance file, refusing names that could escape dir.
+func (c *BalanceCache) path(account string) (string, error) {
+	if account == "" || strings.ContainsAny(account, `/\`) || strings.Contains(account, "..") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidAccountName, account)
+	}
+	return filepath.Join(c.dir, account+".txt"), nil
+}
+
+// Get returns the account's balance, loading it from disk on a cache miss.
+func (c *BalanceCache) Get(account string) (float64, error) {
+	path, err := c.path(account)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if bal, ok := c.cache.Get(account); ok {
+		c.hits++
+		return bal, nil
+	}
+	c.misses++
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("reading balance of %q: %w", account, err)
+	}
+	bal, err := parseBalance(data)
+	if err != nil {
+		return 0, fmt.Errorf("parsing balance of %q: %w", account, err)
+	}
+	c.cache.Put(account, bal)
+	return bal, nil
+}
+
+// Set writes the balance to disk and invalidates the cached copy.
+func (c *BalanceCache) Set(account string, balance float64) error {
+	path, err := c.path(account)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.cache.Remove(account)
+	// atomic, so a concurrent miss never reads a half-written file
+	return atomicfile.WriteFile(path, []byte(formatCents(balance)), 0644)
+}
+
+// Stats reports cache hits and misses so far.
+func (c *BalanceCache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
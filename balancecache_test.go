@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBalanceCacheHitAfterMiss(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "alice.txt", "1200.50\n")
+	c := NewBalanceCache(dir, 4)
+
+	for i := 0; i < 2; i++ {
+		bal, err := c.Get("alice")
+		if err != nil {
+			t.Fatalf("Get #%d: %v", i+1, err)
+		}
+		if bal != 1200.5 {
+			t.Errorf("Get #%d = %v, want 1200.5", i+1, bal)
+		}
+	}
+	if hits, misses := c.Stats(); hits != 1 || misses != 1 {
+		t.Errorf("Stats() = %d hits, %d misses; want 1, 1", hits, misses)
+	}
+}
+
+func TestBalanceCacheSetInvalidates(t *testing.T) {
+	dir := t.TempDir()
+	c := NewBalanceCache(dir, 4)
+
+	if err := c.Set("bob", 10); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	c.Get("bob")
+	if err := c.Set("bob", 25); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	bal, err := c.Get("bob")
+	if err != nil || bal != 25 {
+		t.Errorf("Get after Set = %v, %v; want 25", bal, err)
+	}
+	if _, misses := c.Stats(); misses != 2 {
+		t.Errorf("misses = %d, want 2 (Set must invalidate)", misses)
+	}
+}
+
+func TestBalanceCacheRejectsBadNames(t *testing.T) {
+	dir := t.TempDir()
+	c := NewBalanceCache(filepath.Join(dir, "accounts"), 4)
+	os.Mkdir(filepath.Join(dir, "accounts"), 0755)
+
+	for _, name := range []string{"", "../escape", "a/b", `a\b`, "..", "x..y"} {
+		if err := c.Set(name, 1); !errors.Is(err, ErrInvalidAccountName) {
+			t.Errorf("Set(%q) err = %v, want ErrInvalidAccountName", name, err)
+		}
+		if _, err := c.Get(name); !errors.Is(err, ErrInvalidAccountName) {
+			t.Errorf("Get(%q) err = %v, want ErrInvalidAccountName", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "escape.txt")); !os.IsNotExist(err) {
+		t.Error("a file was written outside the cache directory")
+	}
+}
+
+func TestBalanceCacheRejectsBadContents(t *testing.T) {
+	dir := t.TempDir()
+	c := NewBalanceCache(dir, 4)
+	for name, content := range map[string]string{"nan": "NaN", "inf": "+Inf", "neg": "-5", "junk": "hello"} {
+		writeFixture(t, dir, name+".txt", content)
+		if _, err := c.Get(name); err == nil {
+			t.Errorf("Get(%q) with contents %q should fail", name, content)
+		}
+	}
+}
+
+func TestBalanceCacheSetIsAtomic(t *testing.T) {
+	dir := t.TempDir()
+	c := NewBalanceCache(dir, 4)
+	if err := c.Set("carol", 1); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := range 200 {
+			if err := c.Set("carol", float64(1000+i)); err != nil {
+				t.Errorf("Set: %v", err)
+				return
+			}
+		}
+	}()
+
+	// read the file the way a miss does, while Set keeps rewriting it
+	for {
+		select {
+		case <-done:
+			entries, _ := os.ReadDir(dir)
+			if len(entries) != 1 {
+				t.Errorf("dir holds %d entries, want just carol.txt", len(entries))
+			}
+			return
+		default:
+		}
+		data, err := os.ReadFile(filepath.Join(dir, "carol.txt"))
+		if err == nil {
+			_, err = parseBalance(data)
+		}
+		if err != nil {
+			t.Errorf("read a half-written balance: %v", err)
+			<-done
+			return
+		}
+	}
+}
@@ -1,96 +1,284 @@
 package main
 
 import (
+	"context"
 	"errors"
+	"flag"
 	"fmt"
+	"math"
 	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"example.com/bank/atomicfile"
 )
 
 // control structures, loops, switch-cases, writing to Files, error-handling
 
 const accountBalanceFile = "balance.txt" // global-constant
 
+// currency of the account - decides how many decimals are shown
+const bankCurrency = "USD"
+
+// balance used when there's no (valid) balance file, and for replays
+const defaultBalance = 1000.00
+
+// date interest was last accrued through
+const interestDateFile = "interest.txt"
+
+// withdrawals at or above this amount notify the account-holder
+const largeWithdrawalAmt = 500.00
+
+var bankNotifier Notifier = MultiNotifier{EmailNotifier{}, SMSNotifier{}}
+
 func readBalanceFromFile() (float64, error){
 	data, err := os.ReadFile(accountBalanceFile)
 	// err - file ("balance.txt") might not be present
 	if err != nil{
 		// any logic we want
-		return 1000.00, errors.New("file doesn't exist ⚠️")
+		return defaultBalance, errors.New("file doesn't exist ⚠️")
 	}
-	balanceStr:= string(data)
-	balance,err := strconv.ParseFloat(balanceStr,64)
+	balance,err := parseBalance(data)
 	// err - Not everything can be parsed to a FLOAT (ex: "hello")
 	if err != nil{
 		// any logic we want
-		return 1000.00, errors.New("failed to parse stored balance ⚠️")
+		return defaultBalance, fmt.Errorf("failed to parse stored balance ⚠️: %w",err)
+	}
+	return balance,nil
+}
+
+// parseBalance turns the file contents into a balance.
+// NaN, ±Inf and negative balances are rejected.
+func parseBalance(data []byte) (float64, error){
+	balanceStr:= strings.TrimSpace(string(data))
+	balance,err := strconv.ParseFloat(balanceStr,64)
+	if err != nil{
+		return 0, fmt.Errorf("%q is not a number",balanceStr)
+	}
+	if math.IsNaN(balance) || math.IsInf(balance,0){
+		return 0, fmt.Errorf("%q is not a finite number",balanceStr)
+	}
+	if balance < 0{
+		return 0, errors.New("balance must not be negative")
 	}
 	return balance,nil
 }
 
+// writeBalanceToFile stores the balance with 2 decimals
+func writeBalanceToFile(balance float64) error{
+	balanceStr:= formatCents(balance)
+	return atomicfile.WriteFile(accountBalanceFile,[]byte(balanceStr),0644)
+}
+
+// accrueSinceLastRun credits acc's tier interest for the whole days since the last run.
+// The balance is flushed before the date moves on, so a crash can't lose the interest.
+func accrueSinceLastRun(ctx context.Context, acc *Account, flush func() error) error{
+	now:= time.Now()
+	days,err:= interestDaysSince(interestDateFile,now)
+	if err!=nil{
+		return err
+	}
+	if days > 0{
+		interest,err:= AccrueInterest(ctx,acc,days)
+		if err!=nil{
+			return err
+		}
+		if err:= flush(); err!=nil{
+			return err
+		}
+		if interest > 0{
+			fmt.Printf("💹 %d day(s) of %s interest: +$%s\n",days,acc.Tier(),FormatMoney(interest,bankCurrency))
+		}
+	}
+	return markInterestAccrued(interestDateFile,now)
+}
+
+// scanAmount reads an amount like "50", "$50.00" or "1,200.50" from the user
+func scanAmount() (float64, error){
+	var input string
+	if _,err:= fmt.Scan(&input); err!=nil{
+		return 0, err
+	}
+	minor,err:= ParseAmount(input)
+	if err!=nil{
+		return 0, err
+	}
+	return float64(minor)/100, nil
+}
+
+// runReplay re-executes a command log against a fresh, in-memory balance
+func runReplay(path string){
+	f,err:= os.Open(path)
+	if err!=nil{
+		fmt.Println("ERROR:",err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	fmt.Printf("Replaying %s 📼 (from $%s unless the log opens a session)\n",path,FormatMoney(defaultBalance,bankCurrency))
+	acc,err:= replayCommands(context.Background(),f,os.Stdout)
+	if err!=nil{
+		fmt.Println("ERROR:",err)
+	}
+	fmt.Printf("Final balance: $%s\n",FormatMoney(acc.Balance(),bankCurrency))
+}
+
 func main(){
 
-var accBalance, err = readBalanceFromFile()
+record:= flag.Bool("record", false, "append every successful command to "+commandLogFile)
+replay:= flag.String("replay", "", "replay a command log against a fresh balance, then exit")
+tierName:= flag.String("tier", "bronze", "interest tier of the account: bronze, silver or gold")
+flag.Parse()
+
+tier,err:= ParseTier(*tierName)
+if err!=nil{
+	fmt.Println("ERROR: -tier:",err)
+	os.Exit(2)
+}
+
+// Ctrl+C / SIGTERM cancel ctx, so the pending balance is still flushed below
+ctx,stop:= signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+defer stop()
+exited:= make(chan struct{}) // closed on a normal return, before stop() cancels ctx
+defer close(exited)
+
+if *replay != ""{
+	runReplay(*replay)
+	return
+}
+
+// only one GoBank may touch balance.txt at a time
+releaseLock,err:= AcquireLock(accountBalanceFile+".lock")
+if err!=nil{
+	fmt.Println("ERROR:",err)
+	os.Exit(1)
+}
+defer releaseLock()
+
+accBalance, err := readBalanceFromFile()
 if err !=nil{
 	fmt.Println("ERROR:",err)
 	fmt.Println("----------------------")
 	panic("Exiting the process.. 🔴")
 }
 
+// rapid deposits/withdrawals coalesce into one write ⏳
+saveBalance,flushBalance:= CoalescingWriter(writeBalanceToFile, 500*time.Millisecond)
+defer func(){
+	if err:= flushBalance(); err!=nil{
+		fmt.Println("ERROR saving balance:",err)
+	}
+}()
+
+go func(){
+	<-ctx.Done()
+	select{
+	case <-exited:
+		return // normal exit - the deferred flush already ran
+	default:
+	}
+	fmt.Println("\nInterrupted.. saving balance 💾")
+	if err:= flushBalance(); err!=nil{
+		fmt.Println("ERROR saving balance:",err)
+	}
+	releaseLock()
+	os.Exit(130) // skips main's defers, so flush & release happened above
+}()
+
+acc:= NewAccount(accBalance, debouncedPersist(saveBalance))
+acc.SetTier(tier)
+if err:= accrueSinceLastRun(ctx,acc,flushBalance); err!=nil{
+	fmt.Println("Couldn't accrue interest:",err)
+}
+
+var rec *commandRecorder // nil = not recording
+if *record{
+	if rec,err= startRecording(commandLogFile,acc.Balance()); err!=nil{
+		fmt.Println("Couldn't record commands:",err)
+	}
+}
+
 fmt.Println("WELCOME to GoBank 🏦!")
 
 // for i:=0; i<2; i++{ ❌ // Not needed here..
 // ♾️ loop ☑️
 	for{
-	fmt.Println("\nYour amount is: $",accBalance)
+	fmt.Println("\nYour amount is: $",FormatMoney(acc.Balance(),bankCurrency))
 	fmt.Println("What do you want to do?")
 	fmt.Println("1️⃣. Check balance")
 	fmt.Println("2️⃣. Deposit")
 	fmt.Println("3️⃣. Withdraw")
-	fmt.Println("🔘.OTHER - Exit")
+	fmt.Println("4️⃣. Exit")
 	
-	var choice int
+	// read as text, so garbage input (ex: "abc") doesn't get stuck in Scan
+	var input string
 	fmt.Print("Your choice: ")
-	fmt.Scan(&choice)
+	if _,err:= fmt.Scan(&input); err!=nil{
+		fmt.Println("\nNo more input.. Thanks for choosing GoBank")
+		return
+	}
+	num,err:= strconv.Atoi(input)
+	if err!=nil{
+		fmt.Println("INVALID CHOICE!.. please enter a number from the menu")
+		continue
+	}
+	choice,err:= ParseMenuChoice(num)
+	if err!=nil{
+		fmt.Println("INVALID CHOICE!..",err)
+		continue
+	}
 
 
-// Switch - Alternative to if-else,if,else etc.	
+	var cmd bankCommand
 	switch choice{
-	case 1:
-			fmt.Println("Your balance is: $",accBalance)
-	case 2:
+	case MenuExit:
+	fmt.Println("Exiting.. Thanks for choosing GoBank")
+	return
+	//break
+	case MenuDeposit:
 		fmt.Print("💰 How much do you wanna deposit?: +$")
-		var depositAmt float64 // local scope
-		fmt.Scan(&depositAmt)
-		if depositAmt <=0{
-			fmt.Println("INVALID AMOUNT!.. AMOUNT must not be -ve")
+		depositAmt,err:= scanAmount() // local scope
+		if err!=nil{
+			fmt.Println("INVALID AMOUNT!..",err)
 			continue
 		}
-		accBalance+=float64(depositAmt)
-		formattedBalance:= fmt.Sprintf("%.2f",accBalance)
-		fmt.Println("Deposited ✅.. Your updated account-balance: $",formattedBalance)
-		//writeBalanceToFile(accBalance)
-		//! Writing to file ✍🏻📂
-		os.WriteFile("balance.txt",[]byte(formattedBalance),0644 ) 
-	case 3:
+		cmd = bankCommand{Choice: choice, Amount: depositAmt}
+	case MenuWithdraw:
 		fmt.Print("💰 How much do you wanna withdraw?: -$")
-		var withdrawAmt float64 // local scope
-		fmt.Scan(&withdrawAmt)
-		if withdrawAmt <=0{
-			fmt.Println("INVALID AMOUNT!.. AMOUNT must not be -ve")
+		withdrawAmt,err:= scanAmount() // local scope
+		if err!=nil{
+			fmt.Println("INVALID AMOUNT!..",err)
 			continue
 		}
-		if withdrawAmt>accBalance{
+		if ok,suggestion:= decideWithdrawal(acc.Balance(),withdrawAmt,acc.WithdrawalPolicy()); !ok{
 			fmt.Println("Insufficient Balance :(")
-			continue
+			if suggestion <= 0{
+				continue
+			}
+			fmt.Printf("💡 You can withdraw at most $%s. Withdraw that instead? (y/n): ",FormatMoney(suggestion,bankCurrency))
+			var answer string
+			fmt.Scan(&answer)
+			if !strings.EqualFold(answer,"y"){
+				continue
+			}
+			withdrawAmt = suggestion
 		}
-		accBalance-=float64(withdrawAmt)
-		formattedBalance:= fmt.Sprintf("%.2f",accBalance)
-		fmt.Println("Amount withdrawn ✅.. Your updated account-balance: $",formattedBalance)
+		cmd = bankCommand{Choice: choice, Amount: withdrawAmt}
 	default:
-	fmt.Println("Exiting.. Thanks for choosing GoBank")
-	return
-	//break
+		cmd = bankCommand{Choice: choice}
+	}
+
+	if err:= runCommand(WithTraceID(ctx,newTraceID()),acc,cmd,os.Stdout,rec); err!=nil{
+		continue
+	}
+	if cmd.Choice==MenuWithdraw && cmd.Amount >= largeWithdrawalAmt{
+		msg:= fmt.Sprintf("Large withdrawal of $%s from your GoBank account",FormatMoney(cmd.Amount,bankCurrency))
+		if err:= bankNotifier.Send("account-holder",msg); err!=nil{
+			fmt.Println("Couldn't send notification:",err)
+		}
 	}
 }
-}
\ No newline at end of file
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseBalance(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{"1000.00", 1000, false},
+		{"12.50\n", 12.5, false},
+		{"  0 ", 0, false},
+		{"", 0, true},
+		{"hello", 0, true},
+		{"NaN", 0, true},
+		{"+Inf", 0, true},
+		{"-1", 0, true},
+		{"1e309", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseBalance([]byte(tt.in))
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseBalance(%q) err = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseBalance(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func FuzzParseBalance(f *testing.F) {
+	for _, seed := range []string{"", "NaN", "+Inf", "-1", "1e309", "12.50\n"} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		balance, err := parseBalance(data)
+		if err != nil {
+			return
+		}
+		if math.IsNaN(balance) || math.IsInf(balance, 0) || balance < 0 {
+			t.Errorf("parseBalance(%q) = %v, want a finite, non-negative balance", data, balance)
+		}
+	})
+}
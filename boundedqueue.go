@@ -0,0 +1,65 @@
+package main
+
+import (
+	"errors"
+	"sync"
+)
+
+var ErrQueueClosed = errors.New("queue closed")
+
+// BoundedQueue is a fixed-size FIFO for producer/consumer hand-off, backed by
+// a buffered channel: Put blocks while full, Take blocks while empty 📦
+// ch is never closed (a Put blocked on it would panic); done signals Close instead.
+type BoundedQueue[T any] struct {
+	ch        chan T
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewBoundedQueue[T any](capacity int) *BoundedQueue[T] {
+	return &BoundedQueue[T]{ch: make(chan T, capacity), done: make(chan struct{})}
+}
+
+// Put adds v, blocking while the queue is full.
+// It returns ErrQueueClosed if the queue is closed before or while it waits.
+// A Put racing with Close may still land; Take hands such items out as usual.
+func (q *BoundedQueue[T]) Put(v T) error {
+	select {
+	case <-q.done:
+		return ErrQueueClosed
+	default:
+	}
+	select {
+	case q.ch <- v:
+		return nil
+	case <-q.done:
+		return ErrQueueClosed
+	}
+}
+
+// Take removes the oldest item, blocking while the queue is empty.
+// ok is false once the queue is closed and drained.
+func (q *BoundedQueue[T]) Take() (v T, ok bool) {
+	select {
+	case v = <-q.ch:
+		return v, true
+	case <-q.done:
+	}
+	// closed: keep handing out what's left without blocking
+	select {
+	case v = <-q.ch:
+		return v, true
+	default:
+		return v, false
+	}
+}
+
+// Close wakes up every blocked Put and Take; items already queued can still be taken.
+// Safe to call more than once.
+func (q *BoundedQueue[T]) Close() {
+	q.closeOnce.Do(func() { close(q.done) })
+}
+
+func (q *BoundedQueue[T]) Len() int {
+	return len(q.ch)
+}
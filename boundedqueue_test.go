@@ -0,0 +1,105 @@
+package main
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBoundedQueueProducersConsumers(t *testing.T) {
+	const producers, perProducer = 4, 100
+	q := NewBoundedQueue[int](3)
+
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range perProducer {
+				if err := q.Put(p*perProducer + i); err != nil {
+					t.Errorf("Put: %v", err)
+				}
+			}
+		}()
+	}
+
+	seen := make(chan int, producers*perProducer)
+	var consumers sync.WaitGroup
+	for range 3 {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				v, ok := q.Take()
+				if !ok {
+					return
+				}
+				seen <- v
+			}
+		}()
+	}
+
+	wg.Wait()
+	q.Close()
+	consumers.Wait()
+	close(seen)
+
+	got := map[int]bool{}
+	for v := range seen {
+		if got[v] {
+			t.Errorf("item %d taken twice", v)
+		}
+		got[v] = true
+	}
+	if len(got) != producers*perProducer {
+		t.Errorf("took %d distinct items, want %d", len(got), producers*perProducer)
+	}
+}
+
+func TestBoundedQueueCloseUnblocksPut(t *testing.T) {
+	q := NewBoundedQueue[string](1)
+	if err := q.Put("first"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	errc := make(chan error)
+	go func() { errc <- q.Put("blocked") }()
+	time.Sleep(10 * time.Millisecond) // let Put block on the full queue
+	q.Close()
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, ErrQueueClosed) {
+			t.Errorf("blocked Put = %v, want ErrQueueClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Put still blocked after Close")
+	}
+	if err := q.Put("late"); !errors.Is(err, ErrQueueClosed) {
+		t.Errorf("Put after Close = %v, want ErrQueueClosed", err)
+	}
+	q.Close() // second Close is a no-op
+}
+
+func TestBoundedQueueTakeDrainsAfterClose(t *testing.T) {
+	q := NewBoundedQueue[int](3)
+	for i := 1; i <= 2; i++ {
+		if err := q.Put(i); err != nil {
+			t.Fatalf("Put: %v", err)
+		}
+	}
+	q.Close()
+
+	for want := 1; want <= 2; want++ {
+		if v, ok := q.Take(); !ok || v != want {
+			t.Errorf("Take = %d, %v; want %d, true", v, ok, want)
+		}
+	}
+	if v, ok := q.Take(); ok {
+		t.Errorf("Take on drained queue = %d, true; want ok=false", v)
+	}
+	if n := q.Len(); n != 0 {
+		t.Errorf("Len = %d, want 0", n)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import "cmp"
+
+// BST is a binary search tree with SET semantics: inserting a value that's
+// already present is a no-op, so every value appears once in InOrder 🌲
+type BST[T cmp.Ordered] struct {
+	root *bstNode[T]
+	size int
+}
+
+type bstNode[T cmp.Ordered] struct {
+	value       T
+	left, right *bstNode[T]
+}
+
+// Insert adds v, reporting false if it was already in the tree.
+func (b *BST[T]) Insert(v T) bool {
+	link := &b.root
+	for *link != nil {
+		switch c := cmp.Compare(v, (*link).value); {
+		case c < 0:
+			link = &(*link).left
+		case c > 0:
+			link = &(*link).right
+		default:
+			return false // duplicate - ignored
+		}
+	}
+	*link = &bstNode[T]{value: v}
+	b.size++
+	return true
+}
+
+func (b *BST[T]) Contains(v T) bool {
+	node := b.root
+	for node != nil {
+		switch c := cmp.Compare(v, node.value); {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// InOrder returns all values in ascending order.
+func (b *BST[T]) InOrder() []T {
+	out := make([]T, 0, b.size)
+	var walk func(n *bstNode[T])
+	walk = func(n *bstNode[T]) {
+		if n == nil {
+			return
+		}
+		walk(n.left)
+		out = append(out, n.value)
+		walk(n.right)
+	}
+	walk(b.root)
+	return out
+}
+
+func (b *BST[T]) Len() int {
+	return b.size
+}
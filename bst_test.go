@@ -0,0 +1,55 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestBSTInOrder(t *testing.T) {
+	var b BST[int]
+	for _, v := range []int{50, 30, 70, 20, 40, 60, 80, 35} {
+		if !b.Insert(v) {
+			t.Fatalf("Insert(%d) = false for a new value", v)
+		}
+	}
+
+	if want := []int{20, 30, 35, 40, 50, 60, 70, 80}; !slices.Equal(b.InOrder(), want) {
+		t.Errorf("InOrder() = %v, want %v", b.InOrder(), want)
+	}
+	for _, v := range []int{20, 35, 80} {
+		if !b.Contains(v) {
+			t.Errorf("Contains(%d) = false", v)
+		}
+	}
+	for _, v := range []int{0, 45, 100} {
+		if b.Contains(v) {
+			t.Errorf("Contains(%d) = true", v)
+		}
+	}
+}
+
+func TestBSTIgnoresDuplicates(t *testing.T) {
+	var b BST[string]
+	for _, v := range []string{"m", "c", "x", "c", "m"} {
+		b.Insert(v)
+	}
+	if b.Insert("x") {
+		t.Error("Insert of a duplicate should report false")
+	}
+	if b.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", b.Len())
+	}
+	if want := []string{"c", "m", "x"}; !slices.Equal(b.InOrder(), want) {
+		t.Errorf("InOrder() = %v, want %v", b.InOrder(), want)
+	}
+}
+
+func TestBSTEmpty(t *testing.T) {
+	var b BST[float64]
+	if got := b.InOrder(); len(got) != 0 {
+		t.Errorf("InOrder() = %v on empty tree", got)
+	}
+	if b.Contains(1) {
+		t.Error("Contains on empty tree")
+	}
+}
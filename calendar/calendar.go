@@ -0,0 +1,48 @@
+// Package calendar holds small weekday/business-day helpers 📅
+package calendar
+
+import "time"
+
+// IsWeekend reports whether t falls on a Saturday or Sunday.
+func IsWeekend(t time.Time) bool {
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return true
+	default:
+		return false
+	}
+}
+
+// NextBusinessDay returns the first weekday strictly after t (same clock time).
+func NextBusinessDay(t time.Time) time.Time {
+	next := t.AddDate(0, 0, 1)
+	for IsWeekend(next) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// BusinessDaysBetween counts the weekdays in the date range [a, b).
+// Only the calendar dates matter, not the clock time.
+// If a is after b the count is negative.
+func BusinessDaysBetween(a, b time.Time) int {
+	if a.After(b) {
+		return -BusinessDaysBetween(b, a)
+	}
+
+	day := dateOf(a)
+	end := dateOf(b)
+	count := 0
+	for day.Before(end) {
+		if !IsWeekend(day) {
+			count++
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return count
+}
+
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,51 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func day(d int) time.Time {
+	return time.Date(2025, time.January, d, 10, 30, 0, 0, time.UTC) // Jan 3 2025 is a Friday
+}
+
+func TestIsWeekend(t *testing.T) {
+	for d, want := range map[int]bool{3: false, 4: true, 5: true, 6: false} {
+		if got := IsWeekend(day(d)); got != want {
+			t.Errorf("IsWeekend(Jan %d) = %v, want %v", d, got, want)
+		}
+	}
+}
+
+func TestNextBusinessDay(t *testing.T) {
+	tests := []struct{ from, want int }{
+		{2, 3}, // Thu -> Fri
+		{3, 6}, // Fri -> Mon
+		{4, 6}, // Sat -> Mon
+		{5, 6}, // Sun -> Mon
+	}
+	for _, tt := range tests {
+		if got := NextBusinessDay(day(tt.from)); !got.Equal(day(tt.want)) {
+			t.Errorf("NextBusinessDay(Jan %d) = %v, want Jan %d", tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	tests := []struct {
+		a, b, want int
+	}{
+		{3, 3, 0},   // same day
+		{3, 6, 1},   // Fri -> Mon: only Friday
+		{2, 7, 3},   // Thu -> Tue: Thu, Fri, Mon
+		{4, 6, 0},   // the weekend itself
+		{6, 13, 5},  // a full week
+		{6, 3, -1},  // a after b is negative
+		{13, 2, -7}, // spanning a weekend backwards
+	}
+	for _, tt := range tests {
+		if got := BusinessDaysBetween(day(tt.a), day(tt.b)); got != tt.want {
+			t.Errorf("BusinessDaysBetween(Jan %d, Jan %d) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// channel helpers 📨 - generalized select, pipelines & friends
+
+var ErrTimeout = errors.New("timed out ⏱️")
+
+// SelectN waits for exactly one value from each channel and returns them in
+// the same order as cases. If they don't all arrive within timeout it returns
+// ErrTimeout (values received so far are left in the returned slice).
+func SelectN(timeout time.Duration, cases ...<-chan int) ([]int, error) {
+	results := make([]int, len(cases))
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	// select over a dynamic number of channels -> reflect.Select
+	// last case is always the timer
+	selectCases := make([]reflect.SelectCase, len(cases)+1)
+	for i, ch := range cases {
+		selectCases[i] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)}
+	}
+	timerIdx := len(cases)
+	selectCases[timerIdx] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(timer.C)}
+
+	for remaining := len(cases); remaining > 0; remaining-- {
+		chosen, val, ok := reflect.Select(selectCases)
+		if chosen == timerIdx {
+			return results, ErrTimeout
+		}
+		if !ok {
+			return results, fmt.Errorf("channel %d closed before sending a value", chosen)
+		}
+		results[chosen] = int(val.Int())
+		// a nil channel blocks forever, so this case can't fire again
+		selectCases[chosen].Chan = reflect.Value{}
+	}
+	return results, nil
+}
+
+// indexedResult is a worker output tagged with the index of its input
+type indexedResult[T any] struct {
+	index int
+	value T
+}
+
+// Collect reads n results from ch and puts each one back at its input index,
+// so worker output comes out in input order. It stops early if ch closes.
+func Collect[T any](n int, ch <-chan indexedResult[T]) []T {
+	out := make([]T, n)
+	for i := 0; i < n; i++ {
+		res, ok := <-ch
+		if !ok {
+			break
+		}
+		if res.index >= 0 && res.index < n {
+			out[res.index] = res.value
+		}
+	}
+	return out
+}
+
+// Stage reads every value from in, applies f and sends the result on the
+// returned channel, which is closed once in is closed. Chain stages to build a pipeline.
+func Stage[T, U any](in <-chan T, f func(T) U) <-chan U {
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		for v := range in {
+			out <- f(v)
+		}
+	}()
+	return out
+}
+
+// Generate feeds values into the returned channel, stopping early if ctx is cancelled.
+func Generate[T any](ctx context.Context, values ...T) <-chan T {
+	return GenerateFunc(ctx, len(values), func(i int) T { return values[i] })
+}
+
+// GenerateFunc sends f(0) .. f(n-1) on the returned channel, stopping early if ctx is cancelled.
+// The channel is closed when done either way.
+func GenerateFunc[T any](ctx context.Context, n int, f func(i int) T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for i := 0; i < n; i++ {
+			select {
+			case <-ctx.Done():
+				return
+			case out <- f(i):
+			}
+		}
+	}()
+	return out
+}
+
+// Batch groups values from in into slices of up to size items. A partial batch
+// is flushed once maxWait has passed since its first item, and whatever is left
+// is flushed when in closes (then the output closes too).
+func Batch[T any](in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	if size < 1 {
+		size = 1
+	}
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+
+		var batch []T
+		var timeout <-chan time.Time // nil (blocks forever) while the batch is empty
+		var timer *time.Timer
+		flush := func() {
+			if timer != nil {
+				timer.Stop()
+				timer, timeout = nil, nil
+			}
+			if len(batch) > 0 {
+				out <- batch
+				batch = nil
+			}
+		}
+
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				if len(batch) == 0 {
+					timer = time.NewTimer(maxWait)
+					timeout = timer.C
+				}
+				batch = append(batch, v)
+				if len(batch) >= size {
+					flush()
+				}
+			case <-timeout:
+				timer, timeout = nil, nil
+				flush()
+			}
+		}
+	}()
+	return out
+}
+
+// Drain hands every value still in ch to handle and returns once ch is closed
+// and empty - use it on shutdown so buffered messages aren't lost.
+func Drain[T any](ch <-chan T, handle func(T)) {
+	for v := range ch {
+		handle(v)
+	}
+}
+
+// Tee copies every value from in to all n returned channels (the dual of fan-in).
+// Nothing is dropped: each value is delivered to every output before the next
+// one is read, so the slowest reader sets the pace. Outputs close when in closes.
+func Tee[T any](in <-chan T, n int) []<-chan T {
+	outs := make([]chan T, n)
+	readOnly := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		readOnly[i] = outs[i]
+	}
+
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for v := range in {
+			var wg sync.WaitGroup
+			for _, out := range outs {
+				wg.Add(1)
+				go func() { // send concurrently so one slow reader doesn't block the others' receive
+					defer wg.Done()
+					out <- v
+				}()
+			}
+			wg.Wait()
+		}
+	}()
+	return readOnly
+}
+
+// ReduceChan folds every value from in into an accumulator until in closes.
+// An already-closed, empty channel just gives back init.
+func ReduceChan[T, A any](in <-chan T, init A, f func(A, T) A) A {
+	acc := init
+	for v := range in {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Distinct passes through only the first occurrence of each value, in order.
+// NOTE: every distinct value is remembered in a set for the channel's whole
+// lifetime, so memory grows with the number of unique values.
+func Distinct[T comparable](in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		seen := map[T]struct{}{}
+		for v := range in {
+			if _, dup := seen[v]; dup {
+				continue
+			}
+			seen[v] = struct{}{}
+			out <- v
+		}
+	}()
+	return out
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSelectNAllArrive(t *testing.T) {
+	a, b, c := make(chan int, 1), make(chan int), make(chan int)
+	a <- 1
+	go func() {
+		c <- 3
+		b <- 2
+	}()
+
+	got, err := SelectN(time.Second, a, b, c)
+	if err != nil {
+		t.Fatalf("SelectN: %v", err)
+	}
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("SelectN = %v, want %v", got, want)
+	}
+}
+
+func TestSelectNTimeout(t *testing.T) {
+	a, never := make(chan int, 1), make(chan int)
+	a <- 7
+
+	got, err := SelectN(20*time.Millisecond, a, never)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("err = %v, want ErrTimeout", err)
+	}
+	if got[0] != 7 {
+		t.Errorf("partial results = %v, want first value kept", got)
+	}
+}
+
+func TestSelectNClosedChannel(t *testing.T) {
+	closed := make(chan int)
+	close(closed)
+	if _, err := SelectN(time.Second, closed); err == nil || errors.Is(err, ErrTimeout) {
+		t.Errorf("err = %v, want closed-channel error", err)
+	}
+}
+
+func TestCollectReordersResults(t *testing.T) {
+	ch := make(chan indexedResult[string], 4)
+	for _, res := range []indexedResult[string]{{2, "c"}, {0, "a"}, {3, "d"}, {1, "b"}} {
+		ch <- res
+	}
+
+	got := Collect(4, ch)
+	if want := []string{"a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("Collect = %v, want %v", got, want)
+	}
+}
+
+func TestCollectStopsOnClose(t *testing.T) {
+	ch := make(chan indexedResult[int], 1)
+	ch <- indexedResult[int]{1, 10}
+	close(ch)
+
+	got := Collect(3, ch)
+	if want := []int{0, 10, 0}; !slices.Equal(got, want) {
+		t.Errorf("Collect = %v, want %v", got, want)
+	}
+}
+
+func TestStagePipeline(t *testing.T) {
+	ctx := t.Context()
+	doubled := Stage(Generate(ctx, 1, 2, 3), func(n int) int { return n * 2 })
+	labelled := Stage(doubled, func(n int) string { return fmt.Sprintf("#%d", n) })
+
+	var got []string
+	for s := range labelled {
+		got = append(got, s)
+	}
+	if want := []string{"#2", "#4", "#6"}; !slices.Equal(got, want) {
+		t.Errorf("pipeline = %v, want %v", got, want)
+	}
+}
+
+func TestGenerateFuncStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	ch := GenerateFunc(ctx, 1000, func(i int) int { return i })
+
+	for i := 0; i < 3; i++ {
+		if v := <-ch; v != i {
+			t.Fatalf("value %d = %d", i, v)
+		}
+	}
+	cancel()
+
+	extra := 0
+	for range ch {
+		extra++
+	}
+	// at most one value may already have been racing the cancellation
+	if extra > 1 {
+		t.Errorf("got %d values after cancel, want the generator to stop", extra)
+	}
+}
+
+func TestGenerateAll(t *testing.T) {
+	var got []string
+	for v := range Generate(t.Context(), "x", "y") {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []string{"x", "y"}) {
+		t.Errorf("Generate = %v", got)
+	}
+}
+
+func TestBatchFullBatches(t *testing.T) {
+	in := make(chan int)
+	out := Batch(in, 3, time.Hour)
+	go func() {
+		for i := 1; i <= 6; i++ {
+			in <- i
+		}
+	}()
+
+	for _, want := range [][]int{{1, 2, 3}, {4, 5, 6}} {
+		if got := <-out; !slices.Equal(got, want) {
+			t.Errorf("batch = %v, want %v", got, want)
+		}
+	}
+	close(in)
+	if b, ok := <-out; ok {
+		t.Errorf("unexpected extra batch %v", b)
+	}
+}
+
+func TestBatchFlushesAfterMaxWait(t *testing.T) {
+	in := make(chan int)
+	defer close(in)
+	out := Batch(in, 10, 20*time.Millisecond)
+
+	in <- 1
+	in <- 2
+	select {
+	case got := <-out:
+		if !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("partial batch = %v, want [1 2]", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("partial batch was not flushed after maxWait")
+	}
+}
+
+func TestBatchFlushesRemainderOnClose(t *testing.T) {
+	in := make(chan string, 5)
+	for _, v := range []string{"a", "b", "c", "d", "e"} {
+		in <- v
+	}
+	close(in)
+
+	var got [][]string
+	for b := range Batch(in, 2, time.Hour) {
+		got = append(got, b)
+	}
+	if len(got) != 3 || !slices.Equal(got[2], []string{"e"}) {
+		t.Errorf("batches = %v, want [[a b] [c d] [e]]", got)
+	}
+}
+
+func TestDrainHandlesBuffered(t *testing.T) {
+	emails := make(chan string, 5)
+	for _, to := range []string{"a@x", "b@x", "c@x"} {
+		emails <- to
+	}
+	close(emails)
+
+	var sent []string
+	Drain(emails, func(to string) { sent = append(sent, to) })
+	if want := []string{"a@x", "b@x", "c@x"}; !slices.Equal(sent, want) {
+		t.Errorf("drained %v, want %v", sent, want)
+	}
+}
+
+func TestTeeDeliversEveryValue(t *testing.T) {
+	outs := Tee(Generate(t.Context(), 1, 2, 3, 4), 3)
+
+	results := make([][]int, len(outs))
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range out {
+				results[i] = append(results[i], v)
+				if i == 0 {
+					time.Sleep(time.Millisecond) // a slow reader must not cause drops
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i, got := range results {
+		if !slices.Equal(got, []int{1, 2, 3, 4}) {
+			t.Errorf("output %d got %v, want [1 2 3 4]", i, got)
+		}
+	}
+}
+
+func TestReduceChan(t *testing.T) {
+	sum := ReduceChan(Generate(t.Context(), 1, 2, 3, 4), 0, func(acc, v int) int { return acc + v })
+	if sum != 10 {
+		t.Errorf("sum = %d, want 10", sum)
+	}
+
+	empty := make(chan float64)
+	close(empty)
+	if got := ReduceChan(empty, 42.0, func(acc, v float64) float64 { return acc + v }); got != 42 {
+		t.Errorf("empty channel = %v, want init 42", got)
+	}
+}
+
+func TestDistinct(t *testing.T) {
+	in := Generate(t.Context(), "a@x", "b@x", "a@x", "c@x", "b@x", "a@x")
+	var got []string
+	for v := range Distinct(in) {
+		got = append(got, v)
+	}
+	if want := []string{"a@x", "b@x", "c@x"}; !slices.Equal(got, want) {
+		t.Errorf("Distinct = %v, want %v", got, want)
+	}
+}
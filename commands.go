@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"example.com/bank/metrics"
+)
+
+// commandLogFile is where --record appends every successful command
+const commandLogFile = "commands.log"
+
+// openCommand starts every recorded session with the balance it opened with,
+// ex: "open 250.00", so a replay starts where the live session did
+const openCommand = "open"
+
+// bankCommand is one fully-specified menu action (the prompts already answered),
+// so it can be recorded and replayed without any user input 📼
+type bankCommand struct {
+	Choice MenuChoice
+	Amount float64 // only for deposit/withdraw
+}
+
+// String is the command-log format: "balance", "deposit 50.00", "withdraw 20.00"
+func (c bankCommand) String() string {
+	switch c.Choice {
+	case MenuCheckBalance:
+		return "balance"
+	case MenuDeposit:
+		return "deposit " + formatCents(c.Amount)
+	case MenuWithdraw:
+		return "withdraw " + formatCents(c.Amount)
+	case MenuExit:
+		return "exit"
+	default:
+		return c.Choice.String()
+	}
+}
+
+// commandNames maps the first word of a command-log line to its menu choice
+var commandNames = map[string]MenuChoice{
+	"balance":  MenuCheckBalance,
+	"deposit":  MenuDeposit,
+	"withdraw": MenuWithdraw,
+	"exit":     MenuExit,
+}
+
+// parseBankCommand reads one command-log line back into a bankCommand.
+func parseBankCommand(line string) (bankCommand, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return bankCommand{}, errors.New("empty command")
+	}
+	name, args := fields[0], fields[1:]
+
+	choice, err := ParseEnum(name, commandNames)
+	if err != nil {
+		return bankCommand{}, err
+	}
+	cmd := bankCommand{Choice: choice}
+
+	needsAmount := cmd.Choice == MenuDeposit || cmd.Choice == MenuWithdraw
+	if !needsAmount {
+		if len(args) != 0 {
+			return bankCommand{}, fmt.Errorf("%s takes no arguments", name)
+		}
+		return cmd, nil
+	}
+	if len(args) != 1 {
+		return bankCommand{}, fmt.Errorf("%s needs exactly one amount", name)
+	}
+	minor, err := ParseAmount(args[0])
+	if err != nil {
+		return bankCommand{}, err
+	}
+	cmd.Amount = float64(minor) / 100
+	return cmd, nil
+}
+
+// executeCommand runs cmd against acc and prints the outcome to out.
+// It returns the operation's error (already explained to the user).
+func executeCommand(ctx context.Context, acc *Account, cmd bankCommand, out io.Writer) error {
+	// Switch - Alternative to if-else,if,else etc.
+	switch cmd.Choice {
+	case MenuCheckBalance:
+		fmt.Fprintln(out, "Your balance is: $", FormatMoney(acc.Balance(), bankCurrency))
+	case MenuDeposit:
+		//! Deposit also persists the balance ✍🏻📂
+		if err := Deposit(ctx, acc, cmd.Amount); err != nil {
+			printOperationError(out, err)
+			return err
+		}
+		metrics.Inc("bank.deposits")
+		fmt.Fprintln(out, "Deposited ✅.. Your updated account-balance: $", FormatMoney(acc.Balance(), bankCurrency))
+	case MenuWithdraw:
+		if err := Withdraw(ctx, acc, cmd.Amount); err != nil {
+			printOperationError(out, err)
+			return err
+		}
+		metrics.Inc("bank.withdrawals")
+		fmt.Fprintln(out, "Amount withdrawn ✅.. Your updated account-balance: $", FormatMoney(acc.Balance(), bankCurrency))
+	default:
+		return fmt.Errorf("command %q can't be executed", cmd)
+	}
+	return nil
+}
+
+// printOperationError explains a failed deposit/withdrawal to the user
+func printOperationError(out io.Writer, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidAmount):
+		fmt.Fprintln(out, "INVALID AMOUNT!.. AMOUNT must be positive")
+	case errors.Is(err, ErrInsufficientFunds):
+		fmt.Fprintln(out, "Insufficient Balance :(")
+	default:
+		fmt.Fprintln(out, "ERROR:", err)
+	}
+}
+
+// commandRecorder appends the commands of a live session to a command log 📼
+type commandRecorder struct {
+	path string
+}
+
+// startRecording opens a new session in the log at path, starting from balance.
+func startRecording(path string, balance float64) (*commandRecorder, error) {
+	if err := AppendToFile(path, openCommand+" "+formatCents(balance)+"\n"); err != nil {
+		return nil, err
+	}
+	return &commandRecorder{path: path}, nil
+}
+
+func (r *commandRecorder) record(cmd bankCommand) error {
+	return AppendToFile(r.path, cmd.String()+"\n")
+}
+
+// runCommand executes cmd and, when rec isn't nil, records it. Only commands
+// that succeeded are recorded, so one that failed live (ex: its save failed)
+// can't succeed on replay.
+func runCommand(ctx context.Context, acc *Account, cmd bankCommand, out io.Writer, rec *commandRecorder) error {
+	if err := executeCommand(ctx, acc, cmd, out); err != nil {
+		return err
+	}
+	if rec != nil {
+		if err := rec.record(cmd); err != nil {
+			fmt.Fprintln(out, "Couldn't record command:", err)
+		}
+	}
+	return nil
+}
+
+// replayCommands re-executes a command log, in order, against a fresh in-memory
+// account and returns it. The account starts at defaultBalance, and every
+// "open" line starts over from the balance it names.
+// Lines that can't be replayed are flagged on out and skipped; "exit" stops the replay.
+// Failed operations (ex: insufficient funds) fail again the same way - that's deterministic too.
+func replayCommands(ctx context.Context, r io.Reader, out io.Writer) (*Account, error) {
+	acc := NewAccount(defaultBalance, nil) // never persisted
+	sc := bufio.NewScanner(r)
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		if name, balance, ok := strings.Cut(line, " "); ok && name == openCommand {
+			minor, err := ParseAmount(balance)
+			if err != nil {
+				fmt.Fprintf(out, "⚠️ line %d skipped (not replayable): %v\n", lineNo, err)
+				continue
+			}
+			acc = NewAccount(float64(minor)/100, nil)
+			fmt.Fprintf(out, "📂 session opened with $%s\n", FormatMoney(acc.Balance(), bankCurrency))
+			continue
+		}
+		cmd, err := parseBankCommand(line)
+		if err != nil {
+			fmt.Fprintf(out, "⚠️ line %d skipped (not replayable): %v\n", lineNo, err)
+			continue
+		}
+		if cmd.Choice == MenuExit {
+			break
+		}
+		fmt.Fprintf(out, "▶️ %s\n", cmd)
+		executeCommand(WithTraceID(ctx, newTraceID()), acc, cmd, out) // outcome already printed
+	}
+	return acc, sc.Err()
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBankCommandRoundTrip(t *testing.T) {
+	for _, cmd := range []bankCommand{
+		{Choice: MenuCheckBalance},
+		{Choice: MenuDeposit, Amount: 50},
+		{Choice: MenuWithdraw, Amount: 20.05},
+		{Choice: MenuExit},
+	} {
+		got, err := parseBankCommand(cmd.String())
+		if err != nil {
+			t.Fatalf("parseBankCommand(%q): %v", cmd.String(), err)
+		}
+		if got != cmd {
+			t.Errorf("parseBankCommand(%q) = %+v, want %+v", cmd.String(), got, cmd)
+		}
+	}
+}
+
+func TestParseBankCommandInvalid(t *testing.T) {
+	for _, line := range []string{"", "transfer 10", "deposit", "deposit 1 2", "balance now", "withdraw abc"} {
+		if _, err := parseBankCommand(line); err == nil {
+			t.Errorf("parseBankCommand(%q) should fail", line)
+		}
+	}
+}
+
+func TestReplayCommandsDeterministic(t *testing.T) {
+	log := strings.Join([]string{
+		"deposit 500.00",
+		"withdraw 200.00",
+		"bogus line",
+		"withdraw 5000.00", // fails, every time
+		"balance",
+		"exit",
+		"deposit 1000.00", // never reached
+	}, "\n")
+
+	var balances []float64
+	var outputs []string
+	for i := 0; i < 2; i++ {
+		var out bytes.Buffer
+		acc, err := replayCommands(context.Background(), strings.NewReader(log), &out)
+		if err != nil {
+			t.Fatalf("replayCommands: %v", err)
+		}
+		balances = append(balances, acc.Balance())
+		outputs = append(outputs, out.String())
+	}
+
+	if balances[0] != 1300 || balances[1] != 1300 {
+		t.Errorf("balances = %v, want 1300 both times", balances)
+	}
+	if outputs[0] != outputs[1] {
+		t.Errorf("replays printed different output:\n%s\n---\n%s", outputs[0], outputs[1])
+	}
+	if !strings.Contains(outputs[0], "line 3 skipped") {
+		t.Errorf("bogus line not flagged:\n%s", outputs[0])
+	}
+	if !strings.Contains(outputs[0], "Insufficient Balance") {
+		t.Errorf("failed withdrawal not reported:\n%s", outputs[0])
+	}
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	ctx := context.Background()
+	logPath := filepath.Join(t.TempDir(), "commands.log")
+
+	// the live account saves every balance, except that saving 263.00 fails
+	boom := errors.New("disk full")
+	live := NewAccount(250, func(_ context.Context, balance float64) error {
+		if balance == 263 {
+			return boom
+		}
+		return nil
+	})
+	rec, err := startRecording(logPath, live.Balance())
+	if err != nil {
+		t.Fatalf("startRecording: %v", err)
+	}
+	session := []bankCommand{
+		{Choice: MenuDeposit, Amount: 1000.50},
+		{Choice: MenuWithdraw, Amount: 5000},    // insufficient funds
+		{Choice: MenuWithdraw, Amount: 1250.50}, // the suggested "everything"
+		{Choice: MenuDeposit, Amount: 263},      // save fails
+		{Choice: MenuDeposit, Amount: 40},
+		{Choice: MenuCheckBalance},
+	}
+	for _, cmd := range session {
+		runCommand(ctx, live, cmd, io.Discard, rec)
+	}
+	if live.Balance() != 40 {
+		t.Fatalf("live balance = %v, want 40", live.Balance())
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "open 250.00\ndeposit 1000.50\nwithdraw 1250.50\ndeposit 40.00\nbalance\n"
+	if string(data) != want {
+		t.Errorf("log =\n%s\nwant only the opening balance and successful commands:\n%s", data, want)
+	}
+
+	replayed, err := replayCommands(ctx, bytes.NewReader(data), io.Discard)
+	if err != nil {
+		t.Fatalf("replayCommands: %v", err)
+	}
+	if replayed.Balance() != live.Balance() {
+		t.Errorf("replayed balance = %v, live = %v", replayed.Balance(), live.Balance())
+	}
+}
+
+func TestReplayStartsFromLastOpen(t *testing.T) {
+	log := "open 250.00\ndeposit 10.00\nopen 75.25\nwithdraw 0.25\n"
+	acc, err := replayCommands(context.Background(), strings.NewReader(log), io.Discard)
+	if err != nil {
+		t.Fatalf("replayCommands: %v", err)
+	}
+	if acc.Balance() != 75 {
+		t.Errorf("balance = %v, want 75 (the last session)", acc.Balance())
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// goroutine helpers 🧵
+
+// WaitTimeout waits for wg like wg.Wait(), but gives up after d.
+// Returns true if everything finished in time, false on timeout.
+// On timeout the helper goroutine keeps waiting in the background until wg is done.
+func WaitTimeout(wg *sync.WaitGroup, d time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// CallWithTimeout runs fn in a goroutine and returns ErrTimeout if it takes
+// longer than d. The result channel is buffered, so a late fn still finishes,
+// drops its result and exits instead of leaking blocked forever.
+func CallWithTimeout[T any](d time.Duration, fn func() (T, error)) (T, error) {
+	type result struct {
+		val T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := fn()
+		done <- result{v, err}
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.val, r.err
+	case <-timer.C:
+		var zero T
+		return zero, ErrTimeout
+	}
+}
+
+// Lifecycle tracks background goroutines (workers, janitors, watchers) that
+// share one context, so they can all be stopped together 🛑
+type Lifecycle struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu   sync.Mutex
+	errs []error
+}
+
+func NewLifecycle(parent context.Context) *Lifecycle {
+	ctx, cancel := context.WithCancel(parent)
+	return &Lifecycle{ctx: ctx, cancel: cancel}
+}
+
+// Go starts fn in a tracked goroutine. fn should return once ctx is done;
+// returning context.Canceled counts as a clean exit.
+func (l *Lifecycle) Go(fn func(ctx context.Context) error) {
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		if err := fn(l.ctx); err != nil && !errors.Is(err, context.Canceled) {
+			l.mu.Lock()
+			l.errs = append(l.errs, err)
+			l.mu.Unlock()
+		}
+	}()
+}
+
+// Shutdown cancels the shared context and waits up to timeout for every
+// goroutine to return. The result joins all goroutine errors, plus ErrTimeout
+// if some of them were still running.
+func (l *Lifecycle) Shutdown(timeout time.Duration) error {
+	l.cancel()
+	finished := WaitTimeout(&l.wg, timeout)
+
+	l.mu.Lock()
+	errs := CloneSlice(l.errs)
+	l.mu.Unlock()
+	if !finished {
+		errs = append(errs, fmt.Errorf("shutdown: %w", ErrTimeout))
+	}
+	return errors.Join(errs...)
+}
+
+// PollUntil calls cond right away and then every interval until it returns
+// true (nil), returns an error (that error), or ctx is done (ctx.Err()).
+// A non-positive interval is an error, and cond isn't called at all.
+func PollUntil(ctx context.Context, interval time.Duration, cond func() (bool, error)) error {
+	if interval <= 0 {
+		return fmt.Errorf("PollUntil: interval must be positive, got %v", interval)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		done, err := cond()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWaitTimeoutCompletes(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			time.Sleep(5 * time.Millisecond)
+		}()
+	}
+	if !WaitTimeout(&wg, time.Second) {
+		t.Error("WaitTimeout = false, want true")
+	}
+}
+
+func TestWaitTimeoutTimesOut(t *testing.T) {
+	var wg sync.WaitGroup
+	release := make(chan struct{})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		<-release
+	}()
+	defer close(release)
+
+	start := time.Now()
+	if WaitTimeout(&wg, 20*time.Millisecond) {
+		t.Error("WaitTimeout = true, want false")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitTimeout took %v", elapsed)
+	}
+}
+
+func TestCallWithTimeoutFast(t *testing.T) {
+	got, err := CallWithTimeout(time.Second, func() (string, error) { return "paid", nil })
+	if err != nil || got != "paid" {
+		t.Errorf("CallWithTimeout = %q, %v; want paid, nil", got, err)
+	}
+
+	boom := errors.New("declined")
+	if _, err := CallWithTimeout(time.Second, func() (int, error) { return 0, boom }); !errors.Is(err, boom) {
+		t.Errorf("err = %v, want %v", err, boom)
+	}
+}
+
+func TestCallWithTimeoutSlow(t *testing.T) {
+	finished := make(chan struct{})
+	got, err := CallWithTimeout(10*time.Millisecond, func() (int, error) {
+		defer close(finished)
+		time.Sleep(50 * time.Millisecond)
+		return 42, nil
+	})
+	if !errors.Is(err, ErrTimeout) || got != 0 {
+		t.Errorf("CallWithTimeout = %d, %v; want 0, ErrTimeout", got, err)
+	}
+	select {
+	case <-finished: // the late fn could deliver its result and exit
+	case <-time.After(time.Second):
+		t.Error("late fn never finished (goroutine leaked)")
+	}
+}
+
+func TestLifecycleShutdown(t *testing.T) {
+	l := NewLifecycle(context.Background())
+	boom := errors.New("worker failed")
+
+	var stopped atomic.Int32
+	for i := 0; i < 3; i++ {
+		l.Go(func(ctx context.Context) error {
+			<-ctx.Done()
+			stopped.Add(1)
+			return ctx.Err() // context.Canceled counts as clean
+		})
+	}
+	l.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return boom
+	})
+
+	err := l.Shutdown(time.Second)
+	if !errors.Is(err, boom) || errors.Is(err, ErrTimeout) {
+		t.Errorf("Shutdown = %v, want only %v", err, boom)
+	}
+	if n := stopped.Load(); n != 3 {
+		t.Errorf("%d goroutines stopped, want 3", n)
+	}
+}
+
+func TestLifecycleShutdownTimeout(t *testing.T) {
+	l := NewLifecycle(context.Background())
+	release := make(chan struct{})
+	defer close(release)
+	l.Go(func(context.Context) error {
+		<-release // ignores ctx
+		return nil
+	})
+
+	if err := l.Shutdown(20 * time.Millisecond); !errors.Is(err, ErrTimeout) {
+		t.Errorf("Shutdown = %v, want ErrTimeout", err)
+	}
+}
+
+func TestLifecycleCleanShutdown(t *testing.T) {
+	l := NewLifecycle(context.Background())
+	l.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+	if err := l.Shutdown(time.Second); err != nil {
+		t.Errorf("Shutdown = %v, want nil", err)
+	}
+}
+
+func TestPollUntil(t *testing.T) {
+	ctx := context.Background()
+
+	calls := 0
+	err := PollUntil(ctx, time.Millisecond, func() (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("PollUntil = %v after %d calls, want nil after 3", err, calls)
+	}
+
+	boom := errors.New("payment failed")
+	if err := PollUntil(ctx, time.Millisecond, func() (bool, error) { return false, boom }); !errors.Is(err, boom) {
+		t.Errorf("PollUntil = %v, want %v", err, boom)
+	}
+
+	short, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := PollUntil(short, time.Millisecond, func() (bool, error) { return false, nil }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PollUntil = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestPollUntilNonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Millisecond} {
+		err := PollUntil(context.Background(), interval, func() (bool, error) {
+			t.Error("cond called")
+			return true, nil
+		})
+		if err == nil {
+			t.Errorf("PollUntil(%v) = nil, want an error", interval)
+		}
+	}
+}
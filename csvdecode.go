@@ -0,0 +1,38 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrSkipRow can be returned by a DecodeCSV parse func to drop a row (ex: a header).
+var ErrSkipRow = errors.New("skip row")
+
+// DecodeCSV reads CSV rows from r and hands each one to parse.
+// Any failure is reported with its line number. All rows must have the same
+// number of fields as the first one.
+func DecodeCSV[T any](r io.Reader, parse func([]string) (T, error)) ([]T, error) {
+	cr := csv.NewReader(r)
+
+	var out []T
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return out, nil
+		}
+		if err != nil {
+			return nil, err // *csv.ParseError already names the line
+		}
+		item, err := parse(row)
+		if errors.Is(err, ErrSkipRow) {
+			continue
+		}
+		if err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		out = append(out, item)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// coalescingWriter coalesces rapid updates into a single save once things have
+// been quiet for a while. flush saves any pending value right away, so the last
+// value is never lost as long as flush runs on exit 💾
+type coalescingWriter[T any] struct {
+	save  func(T) error
+	quiet time.Duration
+
+	mu      sync.Mutex
+	pending *T
+	timer   *time.Timer
+	lastErr error
+
+	saveMu sync.Mutex // keeps saves in order (timer vs flush)
+}
+
+// CoalescingWriter returns an update func that schedules v to be saved after
+// the quiet period (replacing any value not saved yet), and a flush func that
+// saves the pending value immediately and returns the last save error.
+//
+// A save that failed in the background is reported by the next update
+// (which then schedules nothing) or flush, so errors don't stay hidden until exit.
+func CoalescingWriter[T any](save func(T) error, quiet time.Duration) (update func(T) error, flush func() error) {
+	w := &coalescingWriter[T]{save: save, quiet: quiet}
+	return w.update, w.flush
+}
+
+func (w *coalescingWriter[T]) update(v T) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.lastErr; err != nil {
+		w.lastErr = nil
+		return err
+	}
+	w.pending = &v
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(w.quiet, w.savePending)
+	return nil
+}
+
+func (w *coalescingWriter[T]) flush() error {
+	w.mu.Lock()
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.mu.Unlock()
+
+	w.savePending()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	err := w.lastErr
+	w.lastErr = nil
+	return err
+}
+
+func (w *coalescingWriter[T]) savePending() {
+	w.saveMu.Lock()
+	defer w.saveMu.Unlock()
+
+	// take the value while holding saveMu, so a newer value is always saved after an older one
+	w.mu.Lock()
+	v := w.pending
+	w.pending = nil
+	w.mu.Unlock()
+	if v == nil {
+		return
+	}
+
+	err := w.save(*v)
+	w.mu.Lock()
+	if err != nil {
+		w.lastErr = err
+	}
+	w.mu.Unlock()
+}
+
+// Throttle returns a func that calls f at most once per d (leading edge):
+// the first call runs f right away, calls during the next d are ignored.
+func Throttle(d time.Duration, f func()) func() {
+	var (
+		mu   sync.Mutex
+		last time.Time
+	)
+	return func() {
+		mu.Lock()
+		now := time.Now()
+		if !last.IsZero() && now.Sub(last) < d {
+			mu.Unlock()
+			return
+		}
+		last = now
+		mu.Unlock()
+		f()
+	}
+}
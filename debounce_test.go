@@ -0,0 +1,173 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// balanceFileSaver writes balances to a temp file and counts the writes.
+type balanceFileSaver struct {
+	path string
+
+	mu     sync.Mutex
+	writes int
+}
+
+func (s *balanceFileSaver) save(balance float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes++
+	return os.WriteFile(s.path, []byte(strconv.FormatFloat(balance, 'f', 2, 64)), 0644)
+}
+
+func (s *balanceFileSaver) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writes
+}
+
+func (s *balanceFileSaver) contents(t *testing.T) string {
+	t.Helper()
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestDebouncedBalanceSave(t *testing.T) {
+	saver := &balanceFileSaver{path: filepath.Join(t.TempDir(), "balance.txt")}
+	update, flush := CoalescingWriter(saver.save, 30*time.Millisecond)
+
+	for _, balance := range []float64{1010, 1020, 1030, 1040} {
+		update(balance)
+	}
+	if err := PollUntil(t.Context(), 5*time.Millisecond, func() (bool, error) {
+		return saver.count() > 0, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(60 * time.Millisecond) // make sure no second write follows
+
+	if n := saver.count(); n != 1 {
+		t.Errorf("%d writes, want 1", n)
+	}
+	if got := saver.contents(t); got != "1040.00" {
+		t.Errorf("file = %q, want 1040.00", got)
+	}
+	if err := flush(); err != nil {
+		t.Errorf("flush: %v", err)
+	}
+	if n := saver.count(); n != 1 {
+		t.Errorf("flush with nothing pending wrote again (%d writes)", n)
+	}
+}
+
+func TestDebouncedBalanceFlushOnExit(t *testing.T) {
+	saver := &balanceFileSaver{path: filepath.Join(t.TempDir(), "balance.txt")}
+	update, flush := CoalescingWriter(saver.save, time.Hour)
+
+	update(1500)
+	update(1250.5)
+	if err := flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if n := saver.count(); n != 1 {
+		t.Errorf("%d writes, want 1", n)
+	}
+	if got := saver.contents(t); got != "1250.50" {
+		t.Errorf("file = %q, want 1250.50 (the last value)", got)
+	}
+}
+
+func TestCoalescingWriterFlushSavesLatest(t *testing.T) {
+	var mu sync.Mutex
+	var saved []string
+	update, flush := CoalescingWriter(func(s string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		saved = append(saved, s)
+		return nil
+	}, time.Hour)
+
+	for _, s := range []string{"draft", "edited", "final"} {
+		if err := update(s); err != nil {
+			t.Fatalf("update(%q): %v", s, err)
+		}
+	}
+	if len(saved) != 0 {
+		t.Fatalf("saved %v before the quiet period", saved)
+	}
+	if err := flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if len(saved) != 1 || saved[0] != "final" {
+		t.Errorf("saved %v, want [final]", saved)
+	}
+}
+
+func TestCoalescingWriterReportsBackgroundError(t *testing.T) {
+	boom := errors.New("disk full")
+	var calls atomic.Int32
+	// quiet is long enough that the timer never fires; savePending below
+	// plays the timer, so the background save has finished when it returns
+	w := &coalescingWriter[int]{save: func(int) error {
+		if calls.Add(1) == 1 {
+			return boom
+		}
+		return nil
+	}, quiet: time.Hour}
+
+	w.update(1)
+	w.savePending()
+
+	if err := w.update(2); !errors.Is(err, boom) {
+		t.Fatalf("update after failed save = %v, want %v", err, boom)
+	}
+	if err := w.update(3); err != nil {
+		t.Fatalf("error should be reported once, got %v", err)
+	}
+	if err := w.flush(); err != nil {
+		t.Errorf("flush: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("save ran %d times, want 2 (update(2) is not scheduled)", n)
+	}
+}
+
+func TestCoalescingWriterFlushReturnsError(t *testing.T) {
+	boom := errors.New("read-only")
+	update, flush := CoalescingWriter(func(int) error { return boom }, time.Hour)
+	update(1)
+	if err := flush(); !errors.Is(err, boom) {
+		t.Errorf("flush = %v, want %v", err, boom)
+	}
+	if err := flush(); err != nil {
+		t.Errorf("second flush = %v, want nil", err)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	var calls atomic.Int32
+	throttled := Throttle(50*time.Millisecond, func() { calls.Add(1) })
+
+	for i := 0; i < 20; i++ {
+		throttled()
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("f ran %d times during a burst, want 1", n)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	throttled()
+	throttled()
+	if n := calls.Load(); n != 2 {
+		t.Errorf("f ran %d times, want 2 after the window passed", n)
+	}
+}
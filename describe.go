@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Describe renders v in a human-readable way for debug logging.
+// Type switch 🔀 - picks a branch based on the dynamic type of v.
+func Describe(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "nil"
+	case int:
+		return fmt.Sprintf("int(%d)", val)
+	case string:
+		return fmt.Sprintf("string(%q)", val)
+	case bool:
+		return fmt.Sprintf("bool(%t)", val)
+	case float64:
+		return fmt.Sprintf("float64(%g)", val)
+	}
+
+	// slices & maps of any element type go through reflection
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, rv.Len())
+		for i := range parts {
+			parts[i] = Describe(rv.Index(i).Interface())
+		}
+		return fmt.Sprintf("%s[%s]", rv.Type(), strings.Join(parts, ", "))
+	case reflect.Map:
+		parts := make([]string, 0, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			parts = append(parts, Describe(iter.Key().Interface())+": "+Describe(iter.Value().Interface()))
+		}
+		sort.Strings(parts) // map order is random, keep output stable
+		return fmt.Sprintf("%s{%s}", rv.Type(), strings.Join(parts, ", "))
+	}
+
+	return fmt.Sprintf("%s(%v)", reflect.TypeOf(v), v)
+}
@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestDescribe(t *testing.T) {
+	type point struct{ X, Y int }
+
+	tests := []struct {
+		name string
+		in   any
+		want string
+	}{
+		{"nil", nil, "nil"},
+		{"int", 42, "int(42)"},
+		{"string", "go", `string("go")`},
+		{"bool", true, "bool(true)"},
+		{"float64", 2.5, "float64(2.5)"},
+		{"slice", []int{1, 2}, "[]int[int(1), int(2)]"},
+		{"empty slice", []string{}, "[]string[]"},
+		{"map", map[string]int{"b": 2, "a": 1}, `map[string]int{string("a"): int(1), string("b"): int(2)}`},
+		{"fallback", point{1, 2}, "main.point({1 2})"},
+		{"fallback uint", uint8(7), "uint8(7)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Describe(tt.in); got != tt.want {
+				t.Errorf("Describe(%#v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
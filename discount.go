@@ -0,0 +1,52 @@
+package main
+
+// Discount rules 🏷️ - each rule takes an amount and returns the discounted amount
+type Discount interface {
+	Apply(amount float32) float32
+}
+
+// PercentOff takes a percentage off (10 == 10%)
+type PercentOff float32
+
+func (p PercentOff) Apply(amount float32) float32 {
+	return amount - amount*float32(p)/100
+}
+
+// FlatOff takes a fixed amount off
+type FlatOff float32
+
+func (f FlatOff) Apply(amount float32) float32 {
+	return amount - float32(f)
+}
+
+// BuyXGetY - for every X+Y units bought, Y of them are free.
+// The amount is treated as a number of units priced at UnitPrice.
+type BuyXGetY struct {
+	X, Y      int
+	UnitPrice float32
+}
+
+func (b BuyXGetY) Apply(amount float32) float32 {
+	if b.X <= 0 || b.Y <= 0 || b.UnitPrice <= 0 {
+		return amount
+	}
+	units := int(amount / b.UnitPrice)
+	free := units / (b.X + b.Y) * b.Y
+	return amount - float32(free)*b.UnitPrice
+}
+
+// stackedDiscount applies its rules one after the other
+type stackedDiscount []Discount
+
+func (s stackedDiscount) Apply(amount float32) float32 {
+	for _, rule := range s {
+		amount = max(rule.Apply(amount), 0) // never below zero
+	}
+	return amount
+}
+
+// StackDiscounts combines rules into one Discount applied in the given order.
+// The running total is floored at zero after every rule.
+func StackDiscounts(rules ...Discount) Discount {
+	return stackedDiscount(rules)
+}
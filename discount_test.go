@@ -0,0 +1,44 @@
+package main
+
+import "testing"
+
+func TestStackDiscounts(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  []Discount
+		amount float32
+		want   float32
+	}{
+		{"percent then flat", []Discount{PercentOff(10), FlatOff(5)}, 100, 85},
+		{"flat then percent", []Discount{FlatOff(5), PercentOff(10)}, 100, 85.5},
+		{"flat larger than total", []Discount{PercentOff(50), FlatOff(80)}, 100, 0},
+		{"floored before next rule", []Discount{FlatOff(200), FlatOff(-10)}, 100, 10},
+		{"no rules", nil, 42, 42},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StackDiscounts(tt.rules...).Apply(tt.amount); got != tt.want {
+				t.Errorf("Apply(%v) = %v, want %v", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuyXGetY(t *testing.T) {
+	rule := BuyXGetY{X: 2, Y: 1, UnitPrice: 10}
+	tests := []struct {
+		amount, want float32
+	}{
+		{20, 20}, // 2 units, nothing free yet
+		{30, 20}, // 3 units, 1 free
+		{70, 50}, // 7 units, 2 free
+	}
+	for _, tt := range tests {
+		if got := rule.Apply(tt.amount); got != tt.want {
+			t.Errorf("Apply(%v) = %v, want %v", tt.amount, got, tt.want)
+		}
+	}
+	if got := (BuyXGetY{}).Apply(30); got != 30 {
+		t.Errorf("zero rule Apply(30) = %v, want 30", got)
+	}
+}
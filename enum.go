@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// EnumParseError is returned when a string isn't one of an enum's known names.
+type EnumParseError struct {
+	Enum  string   // type name, ex: "AccountTier"
+	Value string   // what was given
+	Valid []string // every accepted name, sorted
+}
+
+func (e *EnumParseError) Error() string {
+	return fmt.Sprintf("invalid %s %q (valid: %s)", e.Enum, e.Value, strings.Join(e.Valid, ", "))
+}
+
+// ParseEnum looks s up in table (name -> value) so every enum parser reports
+// bad input the same way - with the list of valid options.
+func ParseEnum[T ~int | ~string](s string, table map[string]T) (T, error) {
+	if v, ok := table[s]; ok {
+		return v, nil
+	}
+	valid := make([]string, 0, len(table))
+	for name := range table {
+		valid = append(valid, name)
+	}
+	slices.Sort(valid)
+
+	var zero T
+	return zero, &EnumParseError{Enum: reflect.TypeFor[T]().Name(), Value: s, Valid: valid}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type orderStatus string // a string-backed enum, like the orders module's
+
+var orderStatusNames = map[string]orderStatus{
+	"pending": "pending",
+	"shipped": "shipped",
+}
+
+func TestParseEnumKnown(t *testing.T) {
+	if got, err := ParseEnum("shipped", orderStatusNames); err != nil || got != "shipped" {
+		t.Errorf("ParseEnum(shipped) = %q, %v", got, err)
+	}
+	if got, err := ParseEnum("gold", tierNames); err != nil || got != Gold {
+		t.Errorf("ParseEnum(gold) = %v, %v", got, err)
+	}
+}
+
+func TestParseEnumError(t *testing.T) {
+	_, err := ParseEnum("platinum", tierNames)
+
+	var perr *EnumParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("err = %v, want *EnumParseError", err)
+	}
+	if perr.Enum != "AccountTier" || perr.Value != "platinum" {
+		t.Errorf("EnumParseError = %+v", perr)
+	}
+	if want := []string{"bronze", "gold", "silver"}; !slices.Equal(perr.Valid, want) {
+		t.Errorf("Valid = %v, want %v (sorted)", perr.Valid, want)
+	}
+	if want := `invalid AccountTier "platinum" (valid: bronze, gold, silver)`; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	_, err = ParseEnum("lost", orderStatusNames)
+	if !errors.As(err, &perr) || perr.Enum != "orderStatus" {
+		t.Errorf("string enum err = %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import "sync"
+
+// subscriberBuffer is how many undelivered messages a subscriber may have queued
+const subscriberBuffer = 16
+
+// EventBus is a tiny topic-based pub/sub 📣
+//
+// Every subscriber gets its own buffered channel. Publish never blocks:
+// if a subscriber's buffer is full (slow consumer) the message is DROPPED for that subscriber only.
+type EventBus struct {
+	mu     sync.RWMutex
+	subs   map[string][]chan any
+	closed bool
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{subs: map[string][]chan any{}}
+}
+
+// Subscribe returns a channel receiving every message published to topic.
+// The channel is closed by Close (and is returned already closed after Close).
+func (b *EventBus) Subscribe(topic string) <-chan any {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan any, subscriberBuffer)
+	if b.closed {
+		close(ch)
+		return ch
+	}
+	b.subs[topic] = append(b.subs[topic], ch)
+	return ch
+}
+
+// Publish fans msg out to all current subscribers of topic without blocking.
+func (b *EventBus) Publish(topic string, msg any) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return
+	}
+	for _, ch := range b.subs[topic] {
+		select {
+		case ch <- msg:
+		default: // subscriber is behind - drop
+		}
+	}
+}
+
+// Close closes every subscriber channel. Publishing afterwards is a no-op.
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, chans := range b.subs {
+		for _, ch := range chans {
+			close(ch)
+		}
+	}
+	b.subs = nil
+}
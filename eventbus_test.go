@@ -0,0 +1,87 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEventBusFanOut(t *testing.T) {
+	bus := NewEventBus()
+	defer bus.Close()
+
+	const n = 5
+	subs := make([]<-chan any, n)
+	for i := range subs {
+		subs[i] = bus.Subscribe("withdrawal")
+	}
+	other := bus.Subscribe("deposit")
+
+	var wg sync.WaitGroup
+	got := make([]any, n)
+	for i, ch := range subs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case got[i] = <-ch:
+			case <-time.After(time.Second):
+			}
+		}()
+	}
+	bus.Publish("withdrawal", 600.0)
+	wg.Wait()
+
+	for i, msg := range got {
+		if msg != 600.0 {
+			t.Errorf("subscriber %d got %v, want 600", i, msg)
+		}
+	}
+	select {
+	case msg := <-other:
+		t.Errorf("deposit subscriber got %v", msg)
+	default:
+	}
+}
+
+func TestEventBusDropsForSlowSubscriber(t *testing.T) {
+	bus := NewEventBus()
+	slow := bus.Subscribe("t")
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < subscriberBuffer*2; i++ {
+			bus.Publish("t", i)
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on a slow subscriber")
+	}
+
+	bus.Close()
+	count := 0
+	for range slow {
+		count++
+	}
+	if count != subscriberBuffer {
+		t.Errorf("slow subscriber got %d messages, want %d (rest dropped)", count, subscriberBuffer)
+	}
+}
+
+func TestEventBusClose(t *testing.T) {
+	bus := NewEventBus()
+	ch := bus.Subscribe("t")
+	bus.Close()
+	bus.Close() // second Close is a no-op
+	bus.Publish("t", "ignored")
+
+	if _, ok := <-ch; ok {
+		t.Error("subscriber channel should be closed")
+	}
+	if _, ok := <-bus.Subscribe("t"); ok {
+		t.Error("Subscribe after Close should return a closed channel")
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// event types for the bank
+const (
+	EventDeposited = "deposited"
+	EventWithdrawn = "withdrawn"
+)
+
+// Event is one fact that happened - state is rebuilt by replaying them (event sourcing) 📼
+type Event struct {
+	Type   string    `json:"type"`
+	Amount float64   `json:"amount"`
+	Time   time.Time `json:"time"`
+}
+
+// EventLog is an append-only file of JSON events, one per line.
+type EventLog struct {
+	path string
+}
+
+func NewEventLog(path string) *EventLog {
+	return &EventLog{path: path}
+}
+
+// Append writes e as one JSON line at the end of the log.
+func (l *EventLog) Append(e Event) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return AppendToFile(l.path, string(data)+"\n")
+}
+
+// Replay streams the events back in order, stopping at the first error from apply.
+// A missing log file just means no events yet.
+func (l *EventLog) Replay(apply func(event Event) error) error {
+	f, err := os.Open(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	for n := 1; ; n++ {
+		var e Event
+		err := dec.Decode(&e)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("event %d: %w", n, err)
+		}
+		if err := apply(e); err != nil {
+			return fmt.Errorf("applying event %d: %w", n, err)
+		}
+	}
+}
+
+// ReplayBalance derives the balance purely from the deposit/withdrawal events.
+func ReplayBalance(l *EventLog) (float64, error) {
+	balance := 0.0
+	err := l.Replay(func(e Event) error {
+		switch e.Type {
+		case EventDeposited:
+			balance += e.Amount
+		case EventWithdrawn:
+			balance -= e.Amount
+		default:
+			return fmt.Errorf("unknown event type %q", e.Type)
+		}
+		return nil
+	})
+	return balance, err
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEventLogReplayBalance(t *testing.T) {
+	log := NewEventLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	now := time.Now()
+	for _, e := range []Event{
+		{Type: EventDeposited, Amount: 1000, Time: now},
+		{Type: EventWithdrawn, Amount: 250, Time: now.Add(time.Second)},
+		{Type: EventDeposited, Amount: 40.5, Time: now.Add(2 * time.Second)},
+	} {
+		if err := log.Append(e); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	var types []string
+	if err := log.Replay(func(e Event) error {
+		types = append(types, e.Type)
+		return nil
+	}); err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if len(types) != 3 || types[1] != EventWithdrawn {
+		t.Errorf("replayed %v, want the 3 events in order", types)
+	}
+
+	balance, err := ReplayBalance(log)
+	if err != nil || balance != 790.5 {
+		t.Errorf("ReplayBalance = %v, %v; want 790.5", balance, err)
+	}
+}
+
+func TestEventLogMissingFile(t *testing.T) {
+	balance, err := ReplayBalance(NewEventLog(filepath.Join(t.TempDir(), "none.jsonl")))
+	if err != nil || balance != 0 {
+		t.Errorf("ReplayBalance = %v, %v; want 0, nil", balance, err)
+	}
+}
+
+func TestEventLogReplayStopsOnError(t *testing.T) {
+	log := NewEventLog(filepath.Join(t.TempDir(), "events.jsonl"))
+	log.Append(Event{Type: EventDeposited, Amount: 1})
+	log.Append(Event{Type: "refunded", Amount: 1})
+	log.Append(Event{Type: EventDeposited, Amount: 1})
+
+	if _, err := ReplayBalance(log); err == nil {
+		t.Error("ReplayBalance should fail on an unknown event type")
+	}
+
+	boom := errors.New("stop")
+	seen := 0
+	err := log.Replay(func(Event) error {
+		seen++
+		return boom
+	})
+	if !errors.Is(err, boom) || seen != 1 {
+		t.Errorf("Replay err = %v after %d events, want %v after 1", err, seen, boom)
+	}
+}
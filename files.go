@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+	"unicode"
+)
+
+// file helpers - checksums, duplicates & friends 📂
+
+// fileChecksum streams the file through sha256 (never loads it fully)
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// FindDuplicates walks root and groups regular files by content checksum.
+// Only groups holding more than one path are returned.
+func FindDuplicates(root string) (map[string][]string, error) {
+	groups := map[string][]string{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		sum, err := fileChecksum(path)
+		if err != nil {
+			return err
+		}
+		groups[sum] = append(groups[sum], path)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for sum, paths := range groups {
+		if len(paths) < 2 {
+			delete(groups, sum)
+		}
+	}
+	return groups, nil
+}
+
+// ListDir returns the entries of the directory at path, sorted by name.
+// Unlike ranging over (*os.File).ReadDir's result, a failed read is returned, never ignored.
+func ListDir(path string) ([]os.DirEntry, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// ReadAllBytes returns the whole content of the file at path.
+func ReadAllBytes(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readAll(f)
+}
+
+// readAll keeps calling Read until io.EOF, since a single Read may return
+// fewer bytes than asked for (short read) without being done.
+func readAll(r io.Reader) ([]byte, error) {
+	buf := make([]byte, 0, 512)
+	for {
+		if len(buf) == cap(buf) {
+			buf = append(buf, 0)[:len(buf)] // grow
+		}
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+		if errors.Is(err, io.EOF) {
+			return buf, nil
+		}
+		if err != nil {
+			return buf, err
+		}
+	}
+}
+
+// AppendToFile appends text to path (creating it with 0644 if missing) and syncs.
+// The text goes out in a single Write so concurrent appends never interleave it.
+func AppendToFile(path, text string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write([]byte(text)); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// defaultWatchInterval is used by WatchFile when interval <= 0
+const defaultWatchInterval = time.Second
+
+// WatchFile polls path every interval and calls onChange whenever its
+// ModTime or Size changes (including it appearing/disappearing).
+// interval <= 0 uses defaultWatchInterval. It blocks until ctx is cancelled.
+func WatchFile(ctx context.Context, path string, interval time.Duration, onChange func()) {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+	stat := func() (time.Time, int64, bool) {
+		info, err := os.Stat(path)
+		if err != nil {
+			return time.Time{}, 0, false
+		}
+		return info.ModTime(), info.Size(), true
+	}
+
+	lastMod, lastSize, lastOK := stat()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			mod, size, ok := stat()
+			if ok != lastOK || !mod.Equal(lastMod) || size != lastSize {
+				lastMod, lastSize, lastOK = mod, size, ok
+				onChange()
+			}
+		}
+	}
+}
+
+// CopyWithProgress copies src into dst, calling onProgress with the running
+// byte count roughly every 1% of total (or every chunk when total is unknown).
+// onProgress always fires once at the end with the final count.
+func CopyWithProgress(dst io.Writer, src io.Reader, total int64, onProgress func(copied int64)) (int64, error) {
+	const chunk = 32 * 1024
+	step := int64(chunk)
+	if total/100 > step {
+		step = total / 100
+	}
+
+	buf := make([]byte, chunk)
+	var copied, reported int64
+	for {
+		n, readErr := src.Read(buf)
+		if n > 0 {
+			if _, err := dst.Write(buf[:n]); err != nil {
+				return copied, err
+			}
+			copied += int64(n)
+			if copied-reported >= step {
+				reported = copied
+				onProgress(copied)
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return copied, readErr
+		}
+	}
+
+	if reported != copied || copied == 0 {
+		onProgress(copied)
+	}
+	return copied, nil
+}
+
+// CopyFile copies src to dst (created or truncated, 0644) through a 32KB
+// buffer, the fast default - see BenchmarkCopyBuffered vs BenchmarkCopyByteByByte.
+func CopyFile(dst, src string) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.CopyBuffer(out, in, make([]byte, 32*1024))
+	if err != nil {
+		out.Close()
+		return n, err
+	}
+	return n, out.Close()
+}
+
+// CountLines streams the file and counts its lines.
+// A last line without a trailing newline still counts.
+func CountLines(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	buf := make([]byte, 32*1024)
+	lines := 0
+	var last byte
+	sawData := false
+	for {
+		n, err := f.Read(buf)
+		if n > 0 {
+			lines += bytes.Count(buf[:n], []byte{'\n'})
+			last = buf[n-1]
+			sawData = true
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if sawData && last != '\n' {
+		lines++
+	}
+	return lines, nil
+}
+
+// CountWords streams the file and counts whitespace-separated words.
+func CountWords(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	words := 0
+	inWord := false
+	for {
+		ch, _, err := r.ReadRune()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if unicode.IsSpace(ch) {
+			inWord = false
+		} else if !inWord {
+			inWord = true
+			words++
+		}
+	}
+	return words, nil
+}
@@ -0,0 +1,437 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func writeFixture(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFindDuplicates(t *testing.T) {
+	dir := t.TempDir()
+	a := writeFixture(t, dir, "a.txt", "same content")
+	b := writeFixture(t, dir, "nested/b.txt", "same content")
+	writeFixture(t, dir, "c.txt", "different content")
+
+	groups, err := FindDuplicates(dir)
+	if err != nil {
+		t.Fatalf("FindDuplicates: %v", err)
+	}
+	if len(groups) != 1 {
+		t.Fatalf("got %d groups, want 1: %v", len(groups), groups)
+	}
+	sum, err := fileChecksum(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := groups[sum]
+	slices.Sort(got)
+	want := []string{a, b}
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("group = %v, want %v", got, want)
+	}
+}
+
+func TestFindDuplicatesMissingRoot(t *testing.T) {
+	if _, err := FindDuplicates(filepath.Join(t.TempDir(), "nope")); err == nil {
+		t.Error("expected an error for a missing root")
+	}
+}
+
+func TestAppendToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.txt")
+	for _, line := range []string{"one\n", "two\n", "three\n"} {
+		if err := AppendToFile(path, line); err != nil {
+			t.Fatalf("AppendToFile(%q): %v", line, err)
+		}
+	}
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "one\ntwo\nthree\n" {
+		t.Errorf("content = %q", got)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0644 != 0644 {
+		t.Errorf("perm = %v, want at least 0644", perm)
+	}
+}
+
+func TestAppendToFileConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.txt")
+	const writers = 20
+	line := func(i int) string {
+		return fmt.Sprintf("writer-%02d:%s\n", i, strings.Repeat("x", 512))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := AppendToFile(path, line(i)); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.SplitAfter(string(data), "\n")
+	got = got[:len(got)-1] // trailing empty piece
+	if len(got) != writers {
+		t.Fatalf("got %d lines, want %d", len(got), writers)
+	}
+	want := make([]string, writers)
+	for i := range want {
+		want[i] = line(i)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Error("appends were interleaved or lost")
+	}
+}
+
+func TestWatchFile(t *testing.T) {
+	path := writeFixture(t, t.TempDir(), "config.json", "{}")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var calls atomic.Int32
+	changed := make(chan struct{}, 10)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		WatchFile(ctx, path, 5*time.Millisecond, func() {
+			calls.Add(1)
+			changed <- struct{}{}
+		})
+	}()
+
+	time.Sleep(20 * time.Millisecond) // let the watcher take its first stat
+	if err := os.WriteFile(path, []byte(`{"reload": true}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("onChange didn't fire after the file changed")
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("WatchFile didn't return after cancel")
+	}
+
+	before := calls.Load()
+	if err := os.WriteFile(path, []byte(`{"after": "cancel"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if after := calls.Load(); after != before {
+		t.Errorf("onChange fired %d more times after cancel", after-before)
+	}
+}
+
+func TestCopyWithProgress(t *testing.T) {
+	src := bytes.Repeat([]byte("gobank"), 200_000) // ~1.2MB, many chunks
+	var dst bytes.Buffer
+	var progress []int64
+
+	n, err := CopyWithProgress(&dst, bytes.NewBuffer(src), int64(len(src)), func(copied int64) {
+		progress = append(progress, copied)
+	})
+	if err != nil {
+		t.Fatalf("CopyWithProgress: %v", err)
+	}
+	if n != int64(len(src)) || !bytes.Equal(dst.Bytes(), src) {
+		t.Fatalf("copied %d bytes, want %d identical bytes", n, len(src))
+	}
+	if len(progress) < 2 {
+		t.Fatalf("expected periodic progress, got %v", progress)
+	}
+	for i := 1; i < len(progress); i++ {
+		if progress[i] <= progress[i-1] {
+			t.Fatalf("progress not monotonic: %v", progress)
+		}
+	}
+	if last := progress[len(progress)-1]; last != int64(len(src)) {
+		t.Errorf("final progress = %d, want %d", last, len(src))
+	}
+}
+
+func TestCopyWithProgressTinyInput(t *testing.T) {
+	for _, input := range []string{"", "hi"} {
+		var dst bytes.Buffer
+		var progress []int64
+		_, err := CopyWithProgress(&dst, bytes.NewBufferString(input), int64(len(input)), func(copied int64) {
+			progress = append(progress, copied)
+		})
+		if err != nil {
+			t.Fatalf("CopyWithProgress(%q): %v", input, err)
+		}
+		if len(progress) != 1 || progress[0] != int64(len(input)) {
+			t.Errorf("CopyWithProgress(%q) progress = %v, want [%d]", input, progress, len(input))
+		}
+	}
+}
+
+// copyByteByByte is the naive copy CopyFile replaces: one Read and one Write per byte.
+func copyByteByByte(dst, src string) (int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return 0, err
+	}
+	defer out.Close()
+
+	var n int64
+	b := make([]byte, 1)
+	for {
+		_, err := in.Read(b)
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		if _, err := out.Write(b); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// copyFixture writes size pseudo-random bytes to a temp file (removed by the test framework).
+func copyFixture(tb testing.TB, size int) string {
+	tb.Helper()
+	data := make([]byte, size)
+	rng := rand.New(rand.NewPCG(1, 2))
+	for i := range data {
+		data[i] = byte(rng.Uint32())
+	}
+	path := filepath.Join(tb.TempDir(), "src.bin")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		tb.Fatal(err)
+	}
+	return path
+}
+
+func TestCopyFileMatchesByteByByte(t *testing.T) {
+	src := copyFixture(t, 100*1024+7)
+	dir := t.TempDir()
+	buffered, naive := filepath.Join(dir, "buffered"), filepath.Join(dir, "naive")
+
+	if _, err := CopyFile(buffered, src); err != nil {
+		t.Fatalf("CopyFile: %v", err)
+	}
+	if _, err := copyByteByByte(naive, src); err != nil {
+		t.Fatalf("copyByteByByte: %v", err)
+	}
+
+	want, _ := os.ReadFile(src)
+	for _, path := range []string{buffered, naive} {
+		got, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s differs from source", filepath.Base(path))
+		}
+	}
+}
+
+func TestCopyFileMissingSource(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := CopyFile(filepath.Join(dir, "out"), filepath.Join(dir, "nope")); err == nil {
+		t.Error("CopyFile with a missing source should fail")
+	}
+}
+
+const benchCopySize = 2 << 20 // 2MB
+
+func benchmarkCopy(b *testing.B, copyFn func(dst, src string) (int64, error)) {
+	src := copyFixture(b, benchCopySize)
+	dst := filepath.Join(b.TempDir(), "dst.bin")
+	b.SetBytes(benchCopySize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := copyFn(dst, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCopyByteByByte(b *testing.B) {
+	benchmarkCopy(b, copyByteByByte)
+}
+
+func BenchmarkCopyBuffered(b *testing.B) {
+	benchmarkCopy(b, CopyFile)
+}
+
+func TestCountLinesAndWords(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name         string
+		content      string
+		lines, words int
+	}{
+		{"empty", "", 0, 0},
+		{"trailing newline", "one two\nthree\n", 2, 3},
+		{"no trailing newline", "one two\nthree", 2, 3},
+		{"blank lines", "\n\n\n", 3, 0},
+		{"single line", "hello", 1, 1},
+		{"ends with NUL", "abc\x00", 1, 1},
+		{"unicode spaces", "héllo wörld\tend", 1, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeFixture(t, dir, tt.name+".txt", tt.content)
+			lines, err := CountLines(path)
+			if err != nil {
+				t.Fatalf("CountLines: %v", err)
+			}
+			if lines != tt.lines {
+				t.Errorf("CountLines = %d, want %d", lines, tt.lines)
+			}
+			words, err := CountWords(path)
+			if err != nil {
+				t.Fatalf("CountWords: %v", err)
+			}
+			if words != tt.words {
+				t.Errorf("CountWords = %d, want %d", words, tt.words)
+			}
+		})
+	}
+}
+
+func TestCountLinesLargeFile(t *testing.T) {
+	// spans several read buffers, and the last line has no newline
+	content := strings.Repeat("a line of text\n", 10000) + "tail"
+	path := writeFixture(t, t.TempDir(), "big.txt", content)
+	lines, err := CountLines(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines != 10001 {
+		t.Errorf("CountLines = %d, want 10001", lines)
+	}
+}
+
+func TestCountLinesMissingFile(t *testing.T) {
+	if _, err := CountLines(filepath.Join(t.TempDir(), "nope")); err == nil {
+		t.Error("CountLines on a missing file should fail")
+	}
+}
+
+func TestWatchFileNonPositiveInterval(t *testing.T) {
+	path := writeFixture(t, t.TempDir(), "config.json", "{}")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		// must fall back to defaultWatchInterval instead of panicking in NewTicker
+		WatchFile(ctx, path, interval, func() { t.Error("onChange fired") })
+	}
+}
+
+func TestListDir(t *testing.T) {
+	dir := t.TempDir()
+	writeFixture(t, dir, "b.txt", "b")
+	writeFixture(t, dir, "a.txt", "a")
+
+	entries, err := ListDir(dir)
+	if err != nil {
+		t.Fatalf("ListDir: %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if want := []string{"a.txt", "b.txt"}; !slices.Equal(names, want) {
+		t.Errorf("ListDir = %v, want %v", names, want)
+	}
+
+	entries, err = ListDir(filepath.Join(dir, "missing"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ListDir(missing) error = %v, want ErrNotExist", err)
+	}
+	if entries != nil {
+		t.Errorf("ListDir(missing) = %v, want nil", entries)
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	want := bytes.Repeat([]byte("0123456789abcdef"), 4096) // 64KB, many reads
+	tests := []struct {
+		name string
+		r    io.Reader
+	}{
+		{"one byte per read", iotest.OneByteReader(bytes.NewReader(want))},
+		{"half of each read", iotest.HalfReader(bytes.NewReader(want))},
+		{"data together with EOF", iotest.DataErrReader(bytes.NewReader(want))},
+	}
+	for _, tt := range tests {
+		got, err := readAll(tt.r)
+		if err != nil || !bytes.Equal(got, want) {
+			t.Errorf("%s: readAll = %d bytes, %v; want %d bytes", tt.name, len(got), err, len(want))
+		}
+	}
+
+	boom := errors.New("disk on fire")
+	if _, err := readAll(io.MultiReader(bytes.NewReader(want[:10]), iotest.ErrReader(boom))); !errors.Is(err, boom) {
+		t.Errorf("readAll error = %v, want %v", err, boom)
+	}
+}
+
+func TestReadAllBytes(t *testing.T) {
+	dir := t.TempDir()
+	content := strings.Repeat("GoBank 🏦\n", 10000) // bigger than one read
+	path := writeFixture(t, dir, "big.txt", content)
+
+	got, err := ReadAllBytes(path)
+	if err != nil || string(got) != content {
+		t.Errorf("ReadAllBytes = %d bytes, %v; want %d bytes", len(got), err, len(content))
+	}
+	if _, err := ReadAllBytes(filepath.Join(dir, "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("ReadAllBytes(missing) error = %v, want ErrNotExist", err)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import "fmt"
+
+// FSM is a tiny finite state machine, e.g. for an order lifecycle
+// ("pending" -> "paid" -> "shipped") 🚦
+type FSM struct {
+	current string
+	allowed func(from, to string) bool // closure over the transition table
+	known   func(state string) bool
+}
+
+// NewFSM builds a machine starting at initial. transitions maps each state
+// to the states it may move to next.
+func NewFSM(initial string, transitions map[string][]string) *FSM {
+	edges := map[string]map[string]bool{}
+	states := map[string]bool{initial: true}
+	for from, tos := range transitions {
+		states[from] = true
+		edges[from] = map[string]bool{}
+		for _, to := range tos {
+			states[to] = true
+			edges[from][to] = true
+		}
+	}
+
+	return &FSM{
+		current: initial,
+		allowed: func(from, to string) bool { return edges[from][to] },
+		known:   func(state string) bool { return states[state] },
+	}
+}
+
+func (f *FSM) Current() string {
+	return f.current
+}
+
+// Can reports whether moving to `to` from the current state is allowed.
+func (f *FSM) Can(to string) bool {
+	return f.allowed(f.current, to)
+}
+
+// Go moves to `to`, or returns an error naming both states if it isn't allowed.
+func (f *FSM) Go(to string) error {
+	if !f.known(to) {
+		return fmt.Errorf("unknown state %q (current state %q)", to, f.current)
+	}
+	if !f.Can(to) {
+		return fmt.Errorf("invalid transition from %q to %q", f.current, to)
+	}
+	f.current = to
+	return nil
+}
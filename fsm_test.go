@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func newOrderFSM() *FSM {
+	return NewFSM("pending", map[string][]string{
+		"pending": {"paid", "cancelled"},
+		"paid":    {"shipped"},
+	})
+}
+
+func TestFSMAllowedTransitions(t *testing.T) {
+	f := newOrderFSM()
+	if !f.Can("paid") {
+		t.Error("Can(paid) = false from pending")
+	}
+	for _, to := range []string{"paid", "shipped"} {
+		if err := f.Go(to); err != nil {
+			t.Fatalf("Go(%q): %v", to, err)
+		}
+	}
+	if f.Current() != "shipped" {
+		t.Errorf("Current() = %q, want shipped", f.Current())
+	}
+}
+
+func TestFSMDisallowedTransition(t *testing.T) {
+	f := newOrderFSM()
+	if f.Can("shipped") {
+		t.Error("Can(shipped) = true from pending")
+	}
+	err := f.Go("shipped")
+	if err == nil {
+		t.Fatal("Go(shipped) from pending should fail")
+	}
+	if msg := err.Error(); !strings.Contains(msg, `"pending"`) || !strings.Contains(msg, `"shipped"`) {
+		t.Errorf("error %q should name both states", msg)
+	}
+	if f.Current() != "pending" {
+		t.Errorf("state changed to %q after a failed Go", f.Current())
+	}
+}
+
+func TestFSMUnknownState(t *testing.T) {
+	f := newOrderFSM()
+	err := f.Go("teleported")
+	if err == nil || !strings.Contains(err.Error(), "unknown state") {
+		t.Errorf("Go(teleported) err = %v, want unknown state error", err)
+	}
+}
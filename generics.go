@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"math"
+)
+
+// small generic helpers 🧰
+
+// If is Go's missing ternary: returns a when cond is true, otherwise b.
+// NOTE: both a & b are evaluated before the call.
+func If[T any](cond bool, a, b T) T {
+	if cond {
+		return a
+	}
+	return b
+}
+
+// Coalesce returns the first non-zero value, or the zero value if all are zero.
+func Coalesce[T comparable](vals ...T) T {
+	var zero T
+	for _, v := range vals {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+// CloneSlice returns an independent copy of s (nil stays nil).
+// The copy is shallow: pointers/slices/maps inside elements are still shared.
+func CloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	out := make([]T, len(s))
+	copy(out, s)
+	return out
+}
+
+// CloneMap returns an independent copy of m (nil stays nil).
+// Like CloneSlice, values are copied shallowly.
+func CloneMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	out := make(map[K]V, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// Number is any built-in integer or float type
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Clamp limits v to the range [lo, hi].
+// If lo > hi the bounds are swapped rather than panicking.
+func Clamp[T cmp.Ordered](v, lo, hi T) T {
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return min(max(v, lo), hi)
+}
+
+// Normalize maps v from [min, max] onto [0, 1], clamping values outside the range.
+// Swapped bounds are treated like Clamp does; an empty range (min == max) gives 0.
+func Normalize[T Number](v, min, max T) float64 {
+	lo, hi := float64(min), float64(max)
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	if lo == hi {
+		return 0
+	}
+	return Clamp((float64(v)-lo)/(hi-lo), 0, 1)
+}
+
+// EqualApprox reports whether a and b have the same length and every pair of
+// elements differs by at most eps. NaN only matches NaN at the same position,
+// and infinities only match the same infinity.
+func EqualApprox(a, b []float64, eps float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		x, y := a[i], b[i]
+		switch {
+		case math.IsNaN(x) || math.IsNaN(y):
+			if !(math.IsNaN(x) && math.IsNaN(y)) {
+				return false
+			}
+		case math.IsInf(x, 0) || math.IsInf(y, 0):
+			if x != y {
+				return false
+			}
+		case math.Abs(x-y) > eps:
+			return false
+		}
+	}
+	return true
+}
+
+// defaultPageSize is used by Paginate when size <= 0
+const defaultPageSize = 10
+
+// Paginate returns the items on the given 1-based page plus the total number of pages.
+// Pages out of range give an empty (nil) slice, and size <= 0 uses defaultPageSize.
+func Paginate[T any](items []T, page, size int) (pageItems []T, totalPages int) {
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	totalPages = (len(items) + size - 1) / size
+	if page < 1 || page > totalPages {
+		return nil, totalPages
+	}
+	start := (page - 1) * size
+	end := min(start+size, len(items))
+	return items[start:end], totalPages
+}
+
+// DiffSlices compares old and new as MULTISETS: every occurrence counts, so
+// [a a] -> [a] reports one "a" removed. added keeps the order of new,
+// removed keeps the order of old.
+func DiffSlices[T comparable](old, new []T) (added, removed []T) {
+	oldCount := map[T]int{}
+	for _, v := range old {
+		oldCount[v]++
+	}
+	newCount := map[T]int{}
+	for _, v := range new {
+		newCount[v]++
+	}
+
+	// the first k occurrences are "matched", the rest are the difference
+	seen := map[T]int{}
+	for _, v := range new {
+		seen[v]++
+		if seen[v] > oldCount[v] {
+			added = append(added, v)
+		}
+	}
+	clear(seen)
+	for _, v := range old {
+		seen[v]++
+		if seen[v] > newCount[v] {
+			removed = append(removed, v)
+		}
+	}
+	return added, removed
+}
+
+// WriteSlice writes items to w separated by sep, buffering everything into a
+// single Write call (much cheaper than one fmt.Println per element).
+// An empty slice writes nothing.
+func WriteSlice[T any](w io.Writer, items []T, sep string) error {
+	var buf bytes.Buffer
+	for i, item := range items {
+		if i > 0 {
+			buf.WriteString(sep)
+		}
+		fmt.Fprintf(&buf, "%v", item)
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// Aggregate groups items by key and folds each group in a single pass,
+// ex: total amount per status. Every group starts from init.
+func Aggregate[T any, K comparable, A any](items []T, key func(T) K, init A, fold func(A, T) A) map[K]A {
+	out := map[K]A{}
+	for _, item := range items {
+		k := key(item)
+		acc, ok := out[k]
+		if !ok {
+			acc = init
+		}
+		out[k] = fold(acc, item)
+	}
+	return out
+}
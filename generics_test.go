@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestIf(t *testing.T) {
+	if got := If(true, 1, 2); got != 1 {
+		t.Errorf("If(true) = %d, want 1", got)
+	}
+	if got := If(false, "yes", "no"); got != "no" {
+		t.Errorf("If(false) = %q, want no", got)
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	if got := Coalesce(0, 0, 3, 4); got != 3 {
+		t.Errorf("Coalesce ints = %d, want 3", got)
+	}
+	if got := Coalesce(0, 0); got != 0 {
+		t.Errorf("Coalesce all-zero ints = %d, want 0", got)
+	}
+	if got := Coalesce[int](); got != 0 {
+		t.Errorf("Coalesce() = %d, want 0", got)
+	}
+	if got := Coalesce("", "first", "second"); got != "first" {
+		t.Errorf("Coalesce strings = %q, want first", got)
+	}
+	if got := Coalesce("", ""); got != "" {
+		t.Errorf("Coalesce all-empty strings = %q, want empty", got)
+	}
+
+	a, b := 1, 2
+	if got := Coalesce(nil, &a, &b); got != &a {
+		t.Errorf("Coalesce pointers = %p, want %p", got, &a)
+	}
+	if got := Coalesce[*int](nil, nil); got != nil {
+		t.Errorf("Coalesce all-nil pointers = %p, want nil", got)
+	}
+}
+
+func TestCloneSlice(t *testing.T) {
+	orig := []int{1, 2, 3}
+	clone := CloneSlice(orig)
+	clone[0] = 99
+	clone = append(clone, 4)
+
+	if orig[0] != 1 || len(orig) != 3 {
+		t.Errorf("original changed: %v", orig)
+	}
+	if CloneSlice[int](nil) != nil {
+		t.Error("CloneSlice(nil) should stay nil")
+	}
+	if got := CloneSlice([]int{}); got == nil || len(got) != 0 {
+		t.Errorf("CloneSlice(empty) = %#v, want empty non-nil", got)
+	}
+}
+
+func TestCloneMap(t *testing.T) {
+	orig := map[string]int{"a": 1, "b": 2}
+	clone := CloneMap(orig)
+	clone["a"] = 99
+	clone["c"] = 3
+	delete(clone, "b")
+
+	if orig["a"] != 1 || orig["b"] != 2 || len(orig) != 2 {
+		t.Errorf("original changed: %v", orig)
+	}
+	if CloneMap[string, int](nil) != nil {
+		t.Error("CloneMap(nil) should stay nil")
+	}
+}
+
+func TestClamp(t *testing.T) {
+	tests := []struct{ v, lo, hi, want int }{
+		{5, 0, 10, 5},
+		{-3, 0, 10, 0},
+		{42, 0, 10, 10},
+		{5, 10, 0, 5}, // swapped bounds
+		{42, 10, 0, 10},
+	}
+	for _, tt := range tests {
+		if got := Clamp(tt.v, tt.lo, tt.hi); got != tt.want {
+			t.Errorf("Clamp(%d, %d, %d) = %d, want %d", tt.v, tt.lo, tt.hi, got, tt.want)
+		}
+	}
+	if got := Clamp("m", "a", "k"); got != "k" {
+		t.Errorf("Clamp strings = %q, want k", got)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		v, min, max float64
+		want        float64
+	}{
+		{50, 0, 100, 0.5},
+		{0, 0, 100, 0},
+		{150, 0, 100, 1},
+		{-10, 0, 100, 0},
+		{25, 100, 0, 0.25}, // swapped bounds
+		{7, 7, 7, 0},       // empty range
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.v, tt.min, tt.max); got != tt.want {
+			t.Errorf("Normalize(%v, %v, %v) = %v, want %v", tt.v, tt.min, tt.max, got, tt.want)
+		}
+	}
+	if got := Normalize[uint8](64, 0, 128); got != 0.5 {
+		t.Errorf("Normalize uint8 = %v, want 0.5", got)
+	}
+}
+
+func TestEqualApprox(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	tests := []struct {
+		name string
+		a, b []float64
+		want bool
+	}{
+		{"within eps", []float64{1, 2.0000001}, []float64{1.0000001, 2}, true},
+		{"outside eps", []float64{1, 2}, []float64{1, 2.1}, false},
+		{"length mismatch", []float64{1}, []float64{1, 2}, false},
+		{"both empty", nil, []float64{}, true},
+		{"NaN matches NaN", []float64{nan, 1}, []float64{nan, 1}, true},
+		{"NaN vs number", []float64{nan}, []float64{0}, false},
+		{"same infinity", []float64{inf}, []float64{inf}, true},
+		{"opposite infinity", []float64{inf}, []float64{-inf}, false},
+		{"infinity vs big number", []float64{inf}, []float64{math.MaxFloat64}, false},
+	}
+	for _, tt := range tests {
+		if got := EqualApprox(tt.a, tt.b, 1e-6); got != tt.want {
+			t.Errorf("%s: EqualApprox = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	tests := []struct {
+		name       string
+		page, size int
+		want       []int
+		wantPages  int
+	}{
+		{"first page", 1, 3, []int{1, 2, 3}, 3},
+		{"last partial page", 3, 3, []int{7}, 3},
+		{"beyond the end", 4, 3, nil, 3},
+		{"page zero", 0, 3, nil, 3},
+		{"default size", 1, 0, []int{1, 2, 3, 4, 5, 6, 7}, 1},
+	}
+	for _, tt := range tests {
+		got, pages := Paginate(items, tt.page, tt.size)
+		if !slices.Equal(got, tt.want) || pages != tt.wantPages {
+			t.Errorf("%s: Paginate(page %d, size %d) = %v, %d; want %v, %d",
+				tt.name, tt.page, tt.size, got, pages, tt.want, tt.wantPages)
+		}
+	}
+	if got, pages := Paginate([]string{}, 1, 5); got != nil || pages != 0 {
+		t.Errorf("empty Paginate = %v, %d; want nil, 0", got, pages)
+	}
+}
+
+func TestDiffSlices(t *testing.T) {
+	tests := []struct {
+		name                string
+		old, new            []string
+		wantAdded, wantRmvd []string
+	}{
+		{"overlapping", []string{"a", "b", "c"}, []string{"b", "c", "d"}, []string{"d"}, []string{"a"}},
+		{"disjoint", []string{"a"}, []string{"b"}, []string{"b"}, []string{"a"}},
+		{"identical", []string{"a", "b"}, []string{"b", "a"}, nil, nil},
+		{"duplicates as multiset", []string{"a", "a", "b"}, []string{"a", "b", "b"}, []string{"b"}, []string{"a"}},
+		{"order of appearance", nil, []string{"z", "y", "x"}, []string{"z", "y", "x"}, nil},
+	}
+	for _, tt := range tests {
+		added, removed := DiffSlices(tt.old, tt.new)
+		if !slices.Equal(added, tt.wantAdded) || !slices.Equal(removed, tt.wantRmvd) {
+			t.Errorf("%s: DiffSlices = +%v -%v, want +%v -%v", tt.name, added, removed, tt.wantAdded, tt.wantRmvd)
+		}
+	}
+}
+
+func TestWriteSlice(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []any
+		sep   string
+		want  string
+	}{
+		{"ints", []any{1, 2, 3}, ", ", "1, 2, 3"},
+		{"newline sep", []any{"a", "b"}, "\n", "a\nb"},
+		{"single item", []any{42}, ",", "42"},
+		{"empty sep", []any{"x", "y"}, "", "xy"},
+		{"empty slice", []any{}, ",", ""},
+		{"nil slice", nil, ",", ""},
+	}
+	for _, tt := range tests {
+		var buf countingWriter
+		if err := WriteSlice(&buf, tt.items, tt.sep); err != nil {
+			t.Fatalf("%s: WriteSlice: %v", tt.name, err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("%s: wrote %q, want %q", tt.name, got, tt.want)
+		}
+		if len(tt.items) == 0 && buf.writes != 0 {
+			t.Errorf("%s: %d writes for an empty slice, want 0", tt.name, buf.writes)
+		}
+		if len(tt.items) > 0 && buf.writes != 1 {
+			t.Errorf("%s: %d writes, want a single Write", tt.name, buf.writes)
+		}
+	}
+}
+
+// countingWriter is a bytes.Buffer that counts Write calls
+type countingWriter struct {
+	bytes.Buffer
+	writes int
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return w.Buffer.Write(p)
+}
+
+// printSliceEach is the per-element approach WriteSlice replaces
+func printSliceEach[T any](w io.Writer, items []T) {
+	for _, item := range items {
+		fmt.Fprintln(w, item)
+	}
+}
+
+var benchSlice = func() []int {
+	s := make([]int, 10000)
+	for i := range s {
+		s[i] = i
+	}
+	return s
+}()
+
+// benchFile gives the benchmarks a real syscall per Write, like os.Stdout
+func benchFile(b *testing.B) *os.File {
+	b.Helper()
+	f, err := os.Create(filepath.Join(b.TempDir(), "out.txt"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { f.Close() })
+	return f
+}
+
+func BenchmarkPrintSliceEach(b *testing.B) {
+	f := benchFile(b)
+	for i := 0; i < b.N; i++ {
+		printSliceEach(f, benchSlice)
+	}
+}
+
+func BenchmarkWriteSlice(b *testing.B) {
+	f := benchFile(b)
+	for i := 0; i < b.N; i++ {
+		if err := WriteSlice(f, benchSlice, "\n"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	type sale struct {
+		status string
+		amount float64
+	}
+	sales := []sale{
+		{"paid", 10}, {"pending", 5}, {"paid", 2.5}, {"refunded", 1}, {"paid", 7.5},
+	}
+
+	totals := Aggregate(sales, func(s sale) string { return s.status }, 0.0,
+		func(acc float64, s sale) float64 { return acc + s.amount })
+	if want := map[string]float64{"paid": 20, "pending": 5, "refunded": 1}; !maps.Equal(totals, want) {
+		t.Errorf("totals = %v, want %v", totals, want)
+	}
+
+	counts := Aggregate(sales, func(s sale) string { return s.status }, 0,
+		func(n int, _ sale) int { return n + 1 })
+	if want := map[string]int{"paid": 3, "pending": 1, "refunded": 1}; !maps.Equal(counts, want) {
+		t.Errorf("counts = %v, want %v", counts, want)
+	}
+
+	if got := Aggregate(nil, func(s sale) string { return s.status }, 0, func(n int, _ sale) int { return n }); len(got) != 0 {
+		t.Errorf("Aggregate(nil) = %v", got)
+	}
+}
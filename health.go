@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// HealthChecker runs named health checks concurrently, each with its own timeout 🩺
+type HealthChecker struct {
+	timeout time.Duration
+
+	mu     sync.Mutex
+	checks map[string]func(ctx context.Context) error
+}
+
+// NewHealthChecker creates a checker giving every check at most timeout to finish.
+func NewHealthChecker(timeout time.Duration) *HealthChecker {
+	return &HealthChecker{timeout: timeout, checks: map[string]func(ctx context.Context) error{}}
+}
+
+// Register adds (or replaces) the check called name.
+func (h *HealthChecker) Register(name string, check func(ctx context.Context) error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.checks[name] = check
+}
+
+// CheckAll runs every check and returns each one's result (nil = healthy).
+// A check that doesn't finish in time reports context.DeadlineExceeded.
+func (h *HealthChecker) CheckAll(ctx context.Context) map[string]error {
+	h.mu.Lock()
+	checks := CloneMap(h.checks)
+	h.mu.Unlock()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		results = make(map[string]error, len(checks))
+	)
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := h.run(ctx, check)
+			mu.Lock()
+			results[name] = err
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// run gives up on the check once the timeout passes, even if it ignores ctx
+func (h *HealthChecker) run(ctx context.Context, check func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	done := make(chan error, 1) // buffered: a late check can still finish & exit
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("health check panicked: %v", r)
+			}
+		}()
+		done <- check(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
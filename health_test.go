@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthCheckerCheckAll(t *testing.T) {
+	h := NewHealthChecker(30 * time.Millisecond)
+	down := errors.New("connection refused")
+
+	h.Register("bank", func(context.Context) error { return nil })
+	h.Register("auth", func(context.Context) error { return down })
+	h.Register("rates", func(ctx context.Context) error {
+		<-ctx.Done() // respects the timeout
+		return ctx.Err()
+	})
+	h.Register("stuck", func(context.Context) error {
+		time.Sleep(time.Second) // ignores ctx entirely
+		return nil
+	})
+	h.Register("buggy", func(context.Context) error { panic("nil map") })
+
+	start := time.Now()
+	results := h.CheckAll(context.Background())
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("CheckAll took %v, checks should run concurrently with a timeout", elapsed)
+	}
+
+	if len(results) != 5 {
+		t.Fatalf("got %d results, want 5", len(results))
+	}
+	if err := results["bank"]; err != nil {
+		t.Errorf("bank = %v, want healthy", err)
+	}
+	if err := results["auth"]; !errors.Is(err, down) {
+		t.Errorf("auth = %v, want %v", err, down)
+	}
+	for _, name := range []string{"rates", "stuck"} {
+		if err := results[name]; !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s = %v, want DeadlineExceeded", name, err)
+		}
+	}
+	if err := results["buggy"]; err == nil || !strings.Contains(err.Error(), "panicked") {
+		t.Errorf("buggy = %v, want a panic error", err)
+	}
+}
+
+func TestHealthCheckerEmpty(t *testing.T) {
+	if got := NewHealthChecker(time.Second).CheckAll(context.Background()); len(got) != 0 {
+		t.Errorf("CheckAll with no checks = %v", got)
+	}
+}
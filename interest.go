@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"example.com/bank/atomicfile"
+)
+
+// InterestStrategy computes the interest earned on principal over a number of days.
+// Swappable (interfaces!) so the bank doesn't care which formula is used 💹
+type InterestStrategy interface {
+	Accrue(principal float64, days int) float64
+}
+
+const daysPerYear = 365
+
+// SimpleInterest - interest only on the principal: P * r * t
+type SimpleInterest struct {
+	Rate float64 // yearly rate, 0.04 == 4%
+}
+
+func (s SimpleInterest) Accrue(principal float64, days int) float64 {
+	return principal * s.Rate * float64(days) / daysPerYear
+}
+
+// CompoundInterest - interest compounded daily: P * ((1 + r/365)^days - 1)
+type CompoundInterest struct {
+	Rate float64 // yearly rate, 0.04 == 4%
+}
+
+func (c CompoundInterest) Accrue(principal float64, days int) float64 {
+	return principal * (math.Pow(1+c.Rate/daysPerYear, float64(days)) - 1)
+}
+
+// Strategy picks the interest strategy for the tier -
+// Bronze earns simple interest, Silver & Gold compound daily.
+func (t AccountTier) Strategy() InterestStrategy {
+	if t == Bronze {
+		return SimpleInterest{Rate: t.Rate()}
+	}
+	return CompoundInterest{Rate: t.Rate()}
+}
+
+// accrueRounded runs the strategy and rounds the interest to cents with mode.
+func accrueRounded(s InterestStrategy, principal float64, days int, mode RoundingMode) float64 {
+	return RoundMoney(s.Accrue(principal, days), mode)
+}
+
+// AccrueInterest credits acc with `days` worth of interest, computed by the
+// strategy of the account's tier and rounded to cents (half-even).
+// It returns the interest credited; like Deposit, nothing changes if persisting fails.
+func AccrueInterest(ctx context.Context, acc *Account, days int) (float64, error) {
+	acc.mu.Lock()
+	defer acc.mu.Unlock()
+
+	interest := accrueRounded(acc.tier.Strategy(), acc.balance, days, HalfEven)
+	if interest <= 0 {
+		return 0, nil
+	}
+	if err := acc.applyLocked(ctx, interest); err != nil {
+		return 0, err
+	}
+	loggerFrom(ctx).Info("interest accrued", "tier", acc.tier, "days", days, "interest", interest)
+	return interest, nil
+}
+
+// interestDaysSince reads the date interest was last accrued through from path
+// and returns how many whole days have passed since then, as of now.
+// A missing file (first run) or a date in the future gives 0.
+func interestDaysSince(path string, now time.Time) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	last, err := time.Parse(time.DateOnly, strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("reading last interest date: %w", err)
+	}
+	today, _ := time.Parse(time.DateOnly, now.Format(time.DateOnly))
+	return max(int(today.Sub(last).Hours()/24), 0), nil
+}
+
+// markInterestAccrued records now's date in path, so the next
+// interestDaysSince only counts the days after it.
+func markInterestAccrued(path string, now time.Time) error {
+	return atomicfile.WriteFile(path, []byte(now.Format(time.DateOnly)), 0644)
+}
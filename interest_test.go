@@ -0,0 +1,89 @@
+package main
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestInterestSimpleVsCompound(t *testing.T) {
+	const principal, rate = 1000.0, 0.04
+
+	simple := SimpleInterest{Rate: rate}.Accrue(principal, 365)
+	compound := CompoundInterest{Rate: rate}.Accrue(principal, 365)
+
+	if math.Abs(simple-40) > 1e-9 {
+		t.Errorf("simple interest = %v, want 40", simple)
+	}
+	want := principal * (math.Pow(1+rate/365, 365) - 1) // ~40.81
+	if math.Abs(compound-want) > 1e-9 {
+		t.Errorf("compound interest = %v, want %v", compound, want)
+	}
+	if compound <= simple {
+		t.Errorf("compound (%v) should beat simple (%v) over a year", compound, simple)
+	}
+}
+
+func TestInterestZeroDays(t *testing.T) {
+	for _, s := range []InterestStrategy{SimpleInterest{Rate: 0.04}, CompoundInterest{Rate: 0.04}} {
+		if got := s.Accrue(1000, 0); got != 0 {
+			t.Errorf("%T.Accrue(1000, 0) = %v, want 0", s, got)
+		}
+	}
+}
+
+func TestTierStrategy(t *testing.T) {
+	if _, ok := Bronze.Strategy().(SimpleInterest); !ok {
+		t.Errorf("Bronze strategy = %T, want SimpleInterest", Bronze.Strategy())
+	}
+	for _, tier := range []AccountTier{Silver, Gold} {
+		s, ok := tier.Strategy().(CompoundInterest)
+		if !ok || s.Rate != tier.Rate() {
+			t.Errorf("%v strategy = %#v, want CompoundInterest at %v", tier, tier.Strategy(), tier.Rate())
+		}
+	}
+}
+
+func TestAccrueRounded(t *testing.T) {
+	got := accrueRounded(CompoundInterest{Rate: 0.04}, 1000, 365, HalfEven)
+	if got != 40.81 {
+		t.Errorf("accrueRounded = %v, want 40.81", got)
+	}
+}
+
+func TestInterestDaysSince(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "interest.txt")
+	now := time.Date(2025, 3, 10, 9, 30, 0, 0, time.UTC)
+
+	if days, err := interestDaysSince(path, now); err != nil || days != 0 {
+		t.Errorf("first run: interestDaysSince = %d, %v; want 0, nil", days, err)
+	}
+	if err := markInterestAccrued(path, now); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		later time.Time
+		want  int
+	}{
+		{now.Add(10 * time.Hour), 0}, // same day
+		{time.Date(2025, 3, 11, 0, 5, 0, 0, time.UTC), 1},
+		{time.Date(2025, 4, 9, 23, 0, 0, 0, time.UTC), 30},
+		{time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), 0}, // clock went backwards
+	}
+	for _, tt := range tests {
+		days, err := interestDaysSince(path, tt.later)
+		if err != nil || days != tt.want {
+			t.Errorf("interestDaysSince(%v) = %d, %v; want %d, nil", tt.later, days, err, tt.want)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("yesterday"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := interestDaysSince(path, now); err == nil {
+		t.Error("interestDaysSince accepted a garbage date")
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// JSON Lines 📜 - one JSON object per line, handy for append-only logs
+
+// WriteJSONL writes each item as one line of JSON.
+func WriteJSONL[T any](w io.Writer, items []T) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw) // Encode adds the trailing '\n' for us
+	for i, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return fmt.Errorf("encoding item %d: %w", i, err)
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadJSONL reads one JSON value per line, skipping blank lines.
+func ReadJSONL[T any](r io.Reader) ([]T, error) {
+	br := bufio.NewReader(r)
+	var items []T
+	for lineNo := 1; ; lineNo++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return items, err
+		}
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			var item T
+			if jsonErr := json.Unmarshal(trimmed, &item); jsonErr != nil {
+				return items, fmt.Errorf("line %d: %w", lineNo, jsonErr)
+			}
+			items = append(items, item)
+		}
+		if errors.Is(err, io.EOF) {
+			return items, nil
+		}
+	}
+}
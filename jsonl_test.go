@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJSONLRoundTrip(t *testing.T) {
+	now := time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+	txns := []Transaction{
+		{Time: now, Category: CategoryDeposit, Amount: 100},
+		{Time: now.Add(time.Hour), Category: CategoryWithdrawal, Amount: -25.5},
+		{Time: now.Add(2 * time.Hour), Category: CategoryFee, Amount: -1},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteJSONL(&buf, txns); err != nil {
+		t.Fatalf("WriteJSONL: %v", err)
+	}
+	if lines := strings.Count(buf.String(), "\n"); lines != len(txns) {
+		t.Fatalf("wrote %d lines, want %d", lines, len(txns))
+	}
+
+	got, err := ReadJSONL[Transaction](&buf)
+	if err != nil {
+		t.Fatalf("ReadJSONL: %v", err)
+	}
+	if !slices.EqualFunc(got, txns, func(a, b Transaction) bool {
+		return a.Time.Equal(b.Time) && a.Category == b.Category && a.Amount == b.Amount
+	}) {
+		t.Errorf("round trip = %+v, want %+v", got, txns)
+	}
+}
+
+func TestReadJSONLBlankLines(t *testing.T) {
+	input := "\n{\"n\": 1}\n   \n{\"n\": 2}" // blank lines and no trailing newline
+	got, err := ReadJSONL[struct{ N int }](strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadJSONL: %v", err)
+	}
+	if len(got) != 2 || got[0].N != 1 || got[1].N != 2 {
+		t.Errorf("ReadJSONL = %+v", got)
+	}
+}
+
+func TestReadJSONLBadLine(t *testing.T) {
+	_, err := ReadJSONL[struct{ N int }](strings.NewReader("{\"n\": 1}\nnot json\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("err = %v, want an error naming line 2", err)
+	}
+}
@@ -0,0 +1,201 @@
+// Package kv is a small goroutine-safe in-memory key/value store with
+// per-key TTLs and JSON snapshots on disk 🔑
+package kv
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"sync"
+	"time"
+
+	"example.com/bank/atomicfile"
+)
+
+type entry struct {
+	Value     []byte    `json:"value"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"` // zero = never expires
+}
+
+func (e entry) expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && !now.Before(e.ExpiresAt)
+}
+
+// Store holds the data. Create it with New and stop its janitor with Close.
+type Store struct {
+	mu        sync.RWMutex
+	data      map[string]entry
+	snapshots map[Token]map[string]entry // taken by Begin
+	nextToken Token
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// New starts a store whose janitor goroutine removes expired keys every
+// janitorInterval (<= 0 disables the janitor; expired keys are still never returned).
+func New(janitorInterval time.Duration) *Store {
+	s := &Store{data: map[string]entry{}, stop: make(chan struct{})}
+	if janitorInterval > 0 {
+		go s.janitor(janitorInterval)
+	}
+	return s
+}
+
+// Set stores a copy of val under key. ttl <= 0 means the key never expires.
+func (s *Store) Set(key string, val []byte, ttl time.Duration) {
+	e := entry{Value: append([]byte(nil), val...)}
+	if ttl > 0 {
+		e.ExpiresAt = time.Now().Add(ttl)
+	}
+	s.mu.Lock()
+	s.data[key] = e
+	s.mu.Unlock()
+}
+
+// Get returns a copy of the value, or false if the key is missing or expired.
+func (s *Store) Get(key string) ([]byte, bool) {
+	s.mu.RLock()
+	e, ok := s.data[key]
+	s.mu.RUnlock()
+	if !ok || e.expired(time.Now()) {
+		return nil, false
+	}
+	return append([]byte(nil), e.Value...), true
+}
+
+func (s *Store) Delete(key string) {
+	s.mu.Lock()
+	delete(s.data, key)
+	s.mu.Unlock()
+}
+
+// Len counts the keys that haven't expired yet.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := time.Now()
+	n := 0
+	for _, e := range s.data {
+		if !e.expired(now) {
+			n++
+		}
+	}
+	return n
+}
+
+// Close stops the janitor goroutine. Safe to call more than once.
+func (s *Store) Close() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+func (s *Store) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case now := <-ticker.C:
+			s.mu.Lock()
+			for k, e := range s.data {
+				if e.expired(now) {
+					delete(s.data, k)
+				}
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// SaveSnapshot writes all live keys to path as JSON (atomically).
+func (s *Store) SaveSnapshot(path string) error {
+	s.mu.RLock()
+	now := time.Now()
+	live := make(map[string]entry, len(s.data))
+	for k, e := range s.data {
+		if !e.expired(now) {
+			live[k] = e
+		}
+	}
+	data, err := json.Marshal(live)
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(path, data, 0644)
+}
+
+// LoadSnapshot replaces the store's contents with the snapshot at path,
+// skipping keys that expired in the meantime.
+func (s *Store) LoadSnapshot(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var loaded map[string]entry
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for k, e := range loaded {
+		if e.expired(now) {
+			delete(loaded, k)
+		}
+	}
+	s.mu.Lock()
+	s.data = loaded
+	if s.data == nil {
+		s.data = map[string]entry{}
+	}
+	s.mu.Unlock()
+	return nil
+}
+
+// Token identifies a snapshot taken by Begin.
+type Token uint64
+
+var ErrUnknownToken = errors.New("kv: unknown or already finished transaction token")
+
+// Begin snapshots the whole store (copy-on-begin) and returns a token for
+// Rollback/Commit. Changes are applied to the store right away as usual.
+func (s *Store) Begin() Token {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.snapshots == nil {
+		s.snapshots = map[Token]map[string]entry{}
+	}
+	s.nextToken++
+	s.snapshots[s.nextToken] = maps.Clone(s.data) // values are never mutated in place, so a shallow copy is enough
+	return s.nextToken
+}
+
+// Rollback restores the store to exactly what it held at Begin - note this
+// also reverts changes made by anyone else since then - and ends the transaction.
+func (s *Store) Rollback(t Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snap, ok := s.snapshots[t]
+	if !ok {
+		return ErrUnknownToken
+	}
+	delete(s.snapshots, t)
+	s.data = snap
+	return nil
+}
+
+// Commit keeps the changes and discards the snapshot.
+func (s *Store) Commit(t Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.snapshots[t]; !ok {
+		return ErrUnknownToken
+	}
+	delete(s.snapshots, t)
+	return nil
+}
@@ -0,0 +1,171 @@
+package kv
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSetGetDelete(t *testing.T) {
+	s := New(0)
+	defer s.Close()
+
+	val := []byte("session-data")
+	s.Set("sid", val, 0)
+	val[0] = 'X' // Set must have copied it
+
+	got, ok := s.Get("sid")
+	if !ok || string(got) != "session-data" {
+		t.Fatalf("Get = %q, %v; want session-data", got, ok)
+	}
+	got[0] = 'Y' // and Get must return a copy
+	if again, _ := s.Get("sid"); string(again) != "session-data" {
+		t.Errorf("stored value changed to %q", again)
+	}
+
+	s.Delete("sid")
+	if _, ok := s.Get("sid"); ok {
+		t.Error("Get after Delete should miss")
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	s := New(5 * time.Millisecond)
+	defer s.Close()
+
+	s.Set("short", []byte("x"), 20*time.Millisecond)
+	s.Set("forever", []byte("y"), 0)
+	if _, ok := s.Get("short"); !ok {
+		t.Fatal("key expired too early")
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if _, ok := s.Get("short"); ok {
+		t.Error("expired key still returned")
+	}
+	if s.Len() != 1 {
+		t.Errorf("Len() = %d, want 1", s.Len())
+	}
+	s.mu.RLock()
+	_, stillThere := s.data["short"]
+	s.mu.RUnlock()
+	if stillThere {
+		t.Error("janitor did not remove the expired key")
+	}
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "kv.json")
+
+	src := New(0)
+	defer src.Close()
+	src.Set("a", []byte("1"), 0)
+	src.Set("b", []byte("2"), time.Hour)
+	src.Set("gone", []byte("3"), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	if err := src.SaveSnapshot(path); err != nil {
+		t.Fatalf("SaveSnapshot: %v", err)
+	}
+
+	dst := New(0)
+	defer dst.Close()
+	dst.Set("stale", []byte("old"), 0)
+	if err := dst.LoadSnapshot(path); err != nil {
+		t.Fatalf("LoadSnapshot: %v", err)
+	}
+
+	for k, want := range map[string]string{"a": "1", "b": "2"} {
+		if got, ok := dst.Get(k); !ok || string(got) != want {
+			t.Errorf("Get(%q) = %q, %v; want %q", k, got, ok, want)
+		}
+	}
+	for _, k := range []string{"gone", "stale"} {
+		if _, ok := dst.Get(k); ok {
+			t.Errorf("Get(%q) should miss after load", k)
+		}
+	}
+
+	matches, _ := filepath.Glob(path + ".tmp-*")
+	if len(matches) != 0 {
+		t.Errorf("temp files left behind: %v", matches)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0644 {
+		t.Errorf("snapshot mode = %v, %v; want 0644", info.Mode().Perm(), err)
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	s := New(time.Millisecond)
+	defer s.Close()
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				key := fmt.Sprintf("k%d-%d", g, i%10)
+				s.Set(key, []byte{byte(i)}, time.Duration(i%3)*time.Millisecond)
+				s.Get(key)
+				if i%7 == 0 {
+					s.Delete(key)
+				}
+				s.Len()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestRollbackRestoresState(t *testing.T) {
+	s := New(0)
+	defer s.Close()
+	s.Set("alice", []byte("100"), 0)
+	s.Set("bob", []byte("50"), 0)
+
+	tok := s.Begin()
+	s.Set("alice", []byte("70"), 0)
+	s.Set("bob", []byte("80"), 0)
+	s.Set("carol", []byte("1"), 0)
+	s.Delete("bob")
+
+	if err := s.Rollback(tok); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	for k, want := range map[string]string{"alice": "100", "bob": "50"} {
+		if got, ok := s.Get(k); !ok || string(got) != want {
+			t.Errorf("Get(%q) = %q, %v; want %q", k, got, ok, want)
+		}
+	}
+	if _, ok := s.Get("carol"); ok {
+		t.Error("key added inside the transaction survived the rollback")
+	}
+	if err := s.Rollback(tok); !errors.Is(err, ErrUnknownToken) {
+		t.Errorf("second Rollback = %v, want ErrUnknownToken", err)
+	}
+}
+
+func TestCommitKeepsChanges(t *testing.T) {
+	s := New(0)
+	defer s.Close()
+	s.Set("alice", []byte("100"), 0)
+
+	tok := s.Begin()
+	s.Set("alice", []byte("70"), 0)
+	if err := s.Commit(tok); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if got, _ := s.Get("alice"); string(got) != "70" {
+		t.Errorf("alice = %q after commit, want 70", got)
+	}
+	if err := s.Rollback(tok); !errors.Is(err, ErrUnknownToken) {
+		t.Errorf("Rollback after Commit = %v, want ErrUnknownToken", err)
+	}
+	if err := s.Commit(Token(999)); !errors.Is(err, ErrUnknownToken) {
+		t.Errorf("Commit(unknown) = %v, want ErrUnknownToken", err)
+	}
+}
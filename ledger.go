@@ -0,0 +1,43 @@
+package main
+
+import "time"
+
+// transaction categories
+const (
+	CategoryDeposit    = "deposit"
+	CategoryWithdrawal = "withdrawal"
+	CategoryFee        = "fee"
+	CategoryInterest   = "interest"
+)
+
+// Transaction is one ledger entry 🧾
+// Amount is signed: money in is positive, money out (withdrawals, fees) is negative.
+type Transaction struct {
+	Time     time.Time `json:"time"`
+	Category string    `json:"category"`
+	Amount   float64   `json:"amount"`
+
+	// tamper detection, filled in by SignLedger
+	PrevMAC string `json:"prev_mac,omitempty"` // MAC of the previous entry ("" for the first)
+	MAC     string `json:"mac,omitempty"`      // HMAC over this entry's fields + PrevMAC
+}
+
+// Summarize totals the amounts per category.
+func Summarize(txns []Transaction) map[string]float64 {
+	totals := map[string]float64{}
+	for _, t := range txns {
+		totals[t.Category] += t.Amount
+	}
+	return totals
+}
+
+// NetChange sums the amounts of transactions in the time range [from, to).
+func NetChange(txns []Transaction, from, to time.Time) float64 {
+	net := 0.0
+	for _, t := range txns {
+		if !t.Time.Before(from) && t.Time.Before(to) {
+			net += t.Amount
+		}
+	}
+	return net
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// CSV layout for the ledger: a header row, then one transaction per row
+//
+//	time,category,amount
+//	2025-01-02T15:04:05Z,deposit,100.00
+var transactionCSVHeader = []string{"time", "category", "amount"}
+
+var knownCategories = map[string]bool{
+	CategoryDeposit:    true,
+	CategoryWithdrawal: true,
+	CategoryFee:        true,
+	CategoryInterest:   true,
+}
+
+// ExportTransactionsCSV writes txns in the ledger CSV layout.
+func ExportTransactionsCSV(w io.Writer, txns []Transaction) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(transactionCSVHeader); err != nil {
+		return err
+	}
+	for _, t := range txns {
+		row := []string{t.Time.Format(time.RFC3339), t.Category, formatCents(t.Amount)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ImportTransactionsCSV parses what ExportTransactionsCSV writes.
+// Errors name the offending line number.
+func ImportTransactionsCSV(r io.Reader) ([]Transaction, error) {
+	return DecodeCSV(r, func(row []string) (Transaction, error) {
+		if slices.Equal(row, transactionCSVHeader) {
+			return Transaction{}, ErrSkipRow
+		}
+		return parseTransactionRow(row)
+	})
+}
+
+func parseTransactionRow(row []string) (Transaction, error) {
+	if len(row) != len(transactionCSVHeader) {
+		return Transaction{}, fmt.Errorf("expected %d fields, got %d", len(transactionCSVHeader), len(row))
+	}
+	when, err := time.Parse(time.RFC3339, row[0])
+	if err != nil {
+		return Transaction{}, fmt.Errorf("invalid time %q", row[0])
+	}
+	if !knownCategories[row[1]] {
+		return Transaction{}, fmt.Errorf("unknown category %q", row[1])
+	}
+	amount, err := strconv.ParseFloat(row[2], 64)
+	if err != nil || math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return Transaction{}, fmt.Errorf("invalid amount %q", row[2])
+	}
+	return Transaction{Time: when, Category: row[1], Amount: amount}, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestTransactionsCSVRoundTrip(t *testing.T) {
+	txns := mixedLedger()
+
+	var buf bytes.Buffer
+	if err := ExportTransactionsCSV(&buf, txns); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "time,category,amount\n") {
+		t.Errorf("missing header:\n%s", buf.String())
+	}
+
+	got, err := ImportTransactionsCSV(&buf)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if len(got) != len(txns) {
+		t.Fatalf("imported %d transactions, want %d", len(got), len(txns))
+	}
+	for i := range txns {
+		if !got[i].Time.Equal(txns[i].Time) || got[i].Category != txns[i].Category || got[i].Amount != txns[i].Amount {
+			t.Errorf("row %d = %+v, want %+v", i, got[i], txns[i])
+		}
+	}
+}
+
+func TestImportTransactionsCSVMalformed(t *testing.T) {
+	tests := []struct {
+		name, row, wantErr string
+	}{
+		{"bad amount", "2025-01-02T15:04:05Z,deposit,lots", "invalid amount"},
+		{"NaN amount", "2025-01-02T15:04:05Z,deposit,NaN", "invalid amount"},
+		{"Inf amount", "2025-01-02T15:04:05Z,deposit,+Inf", "invalid amount"},
+		{"unknown category", "2025-01-02T15:04:05Z,gift,10", "unknown category"},
+		{"bad time", "yesterday,deposit,10", "invalid time"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := "time,category,amount\n2025-01-01T00:00:00Z,deposit,1.00\n" + tt.row + "\n"
+			_, err := ImportTransactionsCSV(strings.NewReader(input))
+			if err == nil {
+				t.Fatal("Import should fail")
+			}
+			if msg := err.Error(); !strings.Contains(msg, "line 3") || !strings.Contains(msg, tt.wantErr) {
+				t.Errorf("err = %q, want line 3 and %q", msg, tt.wantErr)
+			}
+		})
+	}
+}
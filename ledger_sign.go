@@ -0,0 +1,68 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Tamper-evident ledger ⛓️ - every entry's MAC covers its own fields plus the
+// previous entry's MAC (mini blockchain), so editing or reordering any entry
+// breaks the chain from that point on.
+
+// LedgerTamperError names the first entry that failed verification.
+type LedgerTamperError struct {
+	Index  int
+	Reason string
+}
+
+func (e *LedgerTamperError) Error() string {
+	return fmt.Sprintf("ledger entry %d failed verification: %s", e.Index, e.Reason)
+}
+
+// SignLedger returns a copy of txns with PrevMAC/MAC filled in, in order.
+func SignLedger(txns []Transaction, key []byte) []Transaction {
+	signed := CloneSlice(txns)
+	prev := ""
+	for i := range signed {
+		signed[i].PrevMAC = prev
+		signed[i].MAC = hex.EncodeToString(transactionMAC(signed[i], key))
+		prev = signed[i].MAC
+	}
+	return signed
+}
+
+// VerifyLedger checks the chain and returns a *LedgerTamperError for the
+// first altered, inserted or reordered entry (nil if the ledger is intact).
+func VerifyLedger(txns []Transaction, key []byte) error {
+	prev := ""
+	for i, t := range txns {
+		if t.PrevMAC != prev {
+			return &LedgerTamperError{Index: i, Reason: "chain broken (entry missing or reordered)"}
+		}
+		got, err := hex.DecodeString(t.MAC)
+		if err != nil || !hmac.Equal(got, transactionMAC(t, key)) {
+			return &LedgerTamperError{Index: i, Reason: "MAC mismatch (entry altered)"}
+		}
+		prev = t.MAC
+	}
+	return nil
+}
+
+// transactionMAC is the HMAC-SHA256 over the entry's fields and PrevMAC
+func transactionMAC(t Transaction, key []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	for _, field := range []string{
+		t.PrevMAC,
+		t.Time.UTC().Format(time.RFC3339Nano),
+		t.Category,
+		strconv.FormatFloat(t.Amount, 'g', -1, 64),
+	} {
+		// length-prefix each field so ("ab","c") and ("a","bc") differ
+		fmt.Fprintf(mac, "%d:%s|", len(field), field)
+	}
+	return mac.Sum(nil)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+var testLedgerKey = []byte("test-key")
+
+func verifyFails(t *testing.T, txns []Transaction, wantIndex int) {
+	t.Helper()
+	err := VerifyLedger(txns, testLedgerKey)
+	var tamper *LedgerTamperError
+	if !errors.As(err, &tamper) {
+		t.Fatalf("VerifyLedger = %v, want *LedgerTamperError", err)
+	}
+	if tamper.Index != wantIndex {
+		t.Errorf("tamper index = %d, want %d", tamper.Index, wantIndex)
+	}
+}
+
+func TestVerifyLedgerIntact(t *testing.T) {
+	txns := mixedLedger()
+	signed := SignLedger(txns, testLedgerKey)
+	if err := VerifyLedger(signed, testLedgerKey); err != nil {
+		t.Errorf("VerifyLedger on an untouched ledger: %v", err)
+	}
+	if txns[0].MAC != "" {
+		t.Error("SignLedger modified its input")
+	}
+	if err := VerifyLedger(nil, testLedgerKey); err != nil {
+		t.Errorf("empty ledger: %v", err)
+	}
+}
+
+func TestVerifyLedgerDetectsTampering(t *testing.T) {
+	signed := SignLedger(mixedLedger(), testLedgerKey)
+
+	t.Run("altered amount", func(t *testing.T) {
+		txns := slices.Clone(signed)
+		txns[2].Amount = -0.01
+		verifyFails(t, txns, 2)
+	})
+	t.Run("altered category", func(t *testing.T) {
+		txns := slices.Clone(signed)
+		txns[1].Category = CategoryDeposit
+		verifyFails(t, txns, 1)
+	})
+	t.Run("removed entry", func(t *testing.T) {
+		txns := slices.Delete(slices.Clone(signed), 1, 2)
+		verifyFails(t, txns, 1)
+	})
+	t.Run("reordered entries", func(t *testing.T) {
+		txns := slices.Clone(signed)
+		txns[3], txns[4] = txns[4], txns[3]
+		verifyFails(t, txns, 3)
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		if err := VerifyLedger(signed, []byte("other")); err == nil {
+			t.Error("VerifyLedger with the wrong key should fail")
+		}
+	})
+}
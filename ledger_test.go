@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func jan(day int) time.Time {
+	return time.Date(2025, time.January, day, 12, 0, 0, 0, time.UTC)
+}
+
+func mixedLedger() []Transaction {
+	return []Transaction{
+		{Time: jan(1), Category: CategoryDeposit, Amount: 1000},
+		{Time: jan(3), Category: CategoryWithdrawal, Amount: -200},
+		{Time: jan(3), Category: CategoryFee, Amount: -2.5},
+		{Time: jan(10), Category: CategoryDeposit, Amount: 50},
+		{Time: jan(31), Category: CategoryInterest, Amount: 3.25},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	got := Summarize(mixedLedger())
+	want := map[string]float64{
+		CategoryDeposit:    1050,
+		CategoryWithdrawal: -200,
+		CategoryFee:        -2.5,
+		CategoryInterest:   3.25,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Summarize = %v, want %v", got, want)
+	}
+	for cat, total := range want {
+		if math.Abs(got[cat]-total) > 1e-9 {
+			t.Errorf("%s total = %v, want %v", cat, got[cat], total)
+		}
+	}
+	if got := Summarize(nil); len(got) != 0 {
+		t.Errorf("Summarize(nil) = %v", got)
+	}
+}
+
+func TestNetChange(t *testing.T) {
+	txns := mixedLedger()
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     float64
+	}{
+		{"whole month", jan(1), jan(31).Add(time.Second), 850.75},
+		{"to is exclusive", jan(1), jan(10), 797.5},
+		{"from is inclusive", jan(3), jan(4), -202.5},
+		{"empty range", jan(11), jan(20), 0},
+	}
+	for _, tt := range tests {
+		if got := NetChange(txns, tt.from, tt.to); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: NetChange = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
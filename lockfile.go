@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrLocked = errors.New("already locked by another process 🔒")
+
+// staleLockAge - a lock file not touched for this long is assumed to be left over from a crash
+const staleLockAge = 10 * time.Minute
+
+// lockRefreshEvery - the holder touches the lock file this often, so long sessions never look stale
+var lockRefreshEvery = staleLockAge / 4
+
+// AcquireLock creates the lock file at path (O_CREATE|O_EXCL, so only one
+// process can win) and returns a release func that removes it.
+// ErrLocked means someone else holds it; stale lock files are taken over.
+func AcquireLock(path string) (release func(), err error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if errors.Is(err, os.ErrExist) && takeOverStaleLock(path) {
+		f, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	}
+	if errors.Is(err, os.ErrExist) {
+		return nil, fmt.Errorf("%s: %w", path, ErrLocked)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(f, "%d\n", os.Getpid()) // who holds it - handy when debugging
+	f.Close()
+
+	// heartbeat: keep the mtime fresh while we hold the lock
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(lockRefreshEvery)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				now := time.Now()
+				os.Chtimes(path, now, now)
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(stop)
+			<-done // no refresh may recreate the mtime after the remove
+			os.Remove(path)
+		})
+	}, nil
+}
+
+// takeOverStaleLock moves a stale lock file out of the way, reporting whether
+// path is now free. The file is renamed (atomic) rather than removed, and
+// re-checked afterwards: if another process replaced it with a fresh lock in
+// the meantime, that lock is put back and the takeover is abandoned.
+func takeOverStaleLock(path string) bool {
+	if !isStaleLock(path) {
+		return false
+	}
+	aside := fmt.Sprintf("%s.stale-%d-%d", path, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(path, aside); err != nil {
+		return false // gone already, or someone else moved it first
+	}
+	if !isStaleLock(aside) {
+		os.Link(aside, path) // restore it unless a newer lock exists (Link never overwrites)
+		os.Remove(aside)
+		return false
+	}
+	os.Remove(aside)
+	return true
+}
+
+func isStaleLock(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && time.Since(info.ModTime()) > staleLockAge
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func makeStale(t *testing.T, path string) {
+	t.Helper()
+	old := time.Now().Add(-2 * staleLockAge)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAcquireLockExclusive(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "balance.txt.lock")
+
+	release, err := AcquireLock(path)
+	if err != nil {
+		t.Fatalf("AcquireLock: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if pid, _ := strconv.Atoi(strings.TrimSpace(string(data))); pid != os.Getpid() {
+		t.Errorf("lock file holds %q, want our pid", data)
+	}
+
+	if _, err := AcquireLock(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("second AcquireLock = %v, want ErrLocked", err)
+	}
+
+	release()
+	release() // safe to call twice
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("lock file still there after release")
+	}
+	again, err := AcquireLock(path)
+	if err != nil {
+		t.Fatalf("AcquireLock after release: %v", err)
+	}
+	again()
+}
+
+func TestAcquireLockTakesOverStale(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "balance.txt.lock")
+	writeFixture(t, dir, "balance.txt.lock", "12345\n") // left behind by a crash
+	makeStale(t, path)
+
+	release, err := AcquireLock(path)
+	if err != nil {
+		t.Fatalf("AcquireLock over a stale lock: %v", err)
+	}
+	defer release()
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory holds %d entries, want just the new lock", len(entries))
+	}
+}
+
+func TestTakeOverStaleLockKeepsFreshLock(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFixture(t, dir, "balance.txt.lock", "1\n")
+	if takeOverStaleLock(path) {
+		t.Fatal("a fresh lock must not be taken over")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("fresh lock file disturbed: %v", err)
+	}
+}
+
+func TestLockHeartbeatKeepsLockFresh(t *testing.T) {
+	old := lockRefreshEvery
+	lockRefreshEvery = 5 * time.Millisecond
+	defer func() { lockRefreshEvery = old }()
+
+	path := filepath.Join(t.TempDir(), "balance.txt.lock")
+	release, err := AcquireLock(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	makeStale(t, path) // as if the session had been open for a long time
+	if err := PollUntil(t.Context(), 5*time.Millisecond, func() (bool, error) {
+		return !isStaleLock(path), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := AcquireLock(path); !errors.Is(err, ErrLocked) {
+		t.Errorf("AcquireLock on a held lock = %v, want ErrLocked", err)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoizeTTL caches f's results per key for ttl; once a value expires, the
+// next call for that key recomputes it. Safe for concurrent use (two callers
+// racing on a cold key may both compute it - last one wins).
+func MemoizeTTL[K comparable, V any](f func(K) V, ttl time.Duration) func(K) V {
+	type cached struct {
+		val     V
+		expires time.Time
+	}
+	var (
+		mu    sync.Mutex
+		cache = map[K]cached{}
+	)
+	return func(key K) V {
+		mu.Lock()
+		c, ok := cache[key]
+		mu.Unlock()
+		if ok && time.Now().Before(c.expires) {
+			return c.val
+		}
+
+		v := f(key) // computed outside the lock so slow calls don't block other keys
+		mu.Lock()
+		cache[key] = cached{val: v, expires: time.Now().Add(ttl)}
+		mu.Unlock()
+		return v
+	}
+}
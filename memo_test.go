@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoizeTTL(t *testing.T) {
+	calls := map[string]int{}
+	rate := MemoizeTTL(func(cur string) float64 {
+		calls[cur]++
+		return float64(len(cur))
+	}, 30*time.Millisecond)
+
+	rate("USD")
+	rate("USD")
+	rate("EUR")
+	if calls["USD"] != 1 || calls["EUR"] != 1 {
+		t.Fatalf("calls = %v, want one per key while fresh", calls)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if got := rate("USD"); got != 3 {
+		t.Errorf("rate(USD) = %v, want 3", got)
+	}
+	if calls["USD"] != 2 {
+		t.Errorf("USD computed %d times, want 2 after expiry", calls["USD"])
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// MenuChoice - enum for the bank's main menu options 📋
+type MenuChoice int
+
+const (
+	MenuCheckBalance MenuChoice = iota + 1 // menu numbering starts at 1
+	MenuDeposit
+	MenuWithdraw
+	MenuExit
+)
+
+func (m MenuChoice) String() string {
+	switch m {
+	case MenuCheckBalance:
+		return "Check balance"
+	case MenuDeposit:
+		return "Deposit"
+	case MenuWithdraw:
+		return "Withdraw"
+	case MenuExit:
+		return "Exit"
+	default:
+		return fmt.Sprintf("MenuChoice(%d)", int(m))
+	}
+}
+
+var menuChoiceNumbers = map[string]MenuChoice{
+	"1": MenuCheckBalance,
+	"2": MenuDeposit,
+	"3": MenuWithdraw,
+	"4": MenuExit,
+}
+
+// ParseMenuChoice maps the number the user typed to a MenuChoice.
+func ParseMenuChoice(n int) (MenuChoice, error) {
+	return ParseEnum(strconv.Itoa(n), menuChoiceNumbers)
+}
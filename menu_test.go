@@ -0,0 +1,43 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseMenuChoice(t *testing.T) {
+	want := map[int]MenuChoice{
+		1: MenuCheckBalance,
+		2: MenuDeposit,
+		3: MenuWithdraw,
+		4: MenuExit,
+	}
+	for n, choice := range want {
+		got, err := ParseMenuChoice(n)
+		if err != nil {
+			t.Fatalf("ParseMenuChoice(%d): %v", n, err)
+		}
+		if got != choice {
+			t.Errorf("ParseMenuChoice(%d) = %v, want %v", n, got, choice)
+		}
+	}
+}
+
+func TestParseMenuChoiceUnknown(t *testing.T) {
+	for _, n := range []int{0, 5, -1, 42} {
+		_, err := ParseMenuChoice(n)
+		var perr *EnumParseError
+		if !errors.As(err, &perr) {
+			t.Errorf("ParseMenuChoice(%d) err = %v, want *EnumParseError", n, err)
+		}
+	}
+}
+
+func TestMenuChoiceString(t *testing.T) {
+	if got := MenuWithdraw.String(); got != "Withdraw" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := MenuChoice(9).String(); got != "MenuChoice(9)" {
+		t.Errorf("String() = %q", got)
+	}
+}
@@ -0,0 +1,42 @@
+// Package metrics keeps named, concurrency-safe counters 📊
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var counters sync.Map // name -> *atomic.Int64
+
+func counter(name string) *atomic.Int64 {
+	if c, ok := counters.Load(name); ok {
+		return c.(*atomic.Int64)
+	}
+	c, _ := counters.LoadOrStore(name, new(atomic.Int64))
+	return c.(*atomic.Int64)
+}
+
+// Inc adds 1 to the named counter.
+func Inc(name string) {
+	counter(name).Add(1)
+}
+
+// Add adds n to the named counter.
+func Add(name string, n int64) {
+	counter(name).Add(n)
+}
+
+// Snapshot returns the current value of every counter.
+func Snapshot() map[string]int64 {
+	out := map[string]int64{}
+	counters.Range(func(key, value any) bool {
+		out[key.(string)] = value.(*atomic.Int64).Load()
+		return true
+	})
+	return out
+}
+
+// reset drops every counter, so tests start from zero.
+func reset() {
+	counters.Clear()
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestConcurrentCounters(t *testing.T) {
+	reset()
+	const goroutines, perG = 50, 200
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perG; j++ {
+				Inc("test.deposits")
+				Add("test.amount", 5)
+			}
+		}()
+	}
+	wg.Wait()
+
+	snap := Snapshot()
+	if got := snap["test.deposits"]; got != goroutines*perG {
+		t.Errorf("test.deposits = %d, want %d", got, goroutines*perG)
+	}
+	if got := snap["test.amount"]; got != goroutines*perG*5 {
+		t.Errorf("test.amount = %d, want %d", got, goroutines*perG*5)
+	}
+}
+
+func TestSnapshotIsACopy(t *testing.T) {
+	reset()
+	Inc("test.copy")
+	snap := Snapshot()
+	snap["test.copy"] = 1000
+	Inc("test.copy")
+
+	if got := Snapshot()["test.copy"]; got != 2 {
+		t.Errorf("test.copy = %d, want 2", got)
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrNegativeMoney    = errors.New("result would be negative")
+)
+
+// Money is an amount in MINOR units (cents, paise..) plus its currency code.
+// How many minor units make one major unit depends on the currency (see currencyDecimals).
+// Integers avoid float rounding surprises 💵
+type Money struct {
+	Amount   int64
+	Currency string // ISO code, ex: "USD"
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: strings.ToUpper(currency)}
+}
+
+func (m Money) String() string {
+	sign := ""
+	amt := m.Amount
+	if amt < 0 {
+		sign, amt = "-", -amt
+	}
+	dec := decimalsFor(m.Currency)
+	if dec == 0 {
+		return fmt.Sprintf("%s%d %s", sign, amt, m.Currency)
+	}
+	scale := int64(math.Pow10(dec))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amt/scale, dec, amt%scale, m.Currency)
+}
+
+// Equal reports whether both the currency and the amount match.
+func (m Money) Equal(other Money) bool {
+	return m.sameCurrency(other) && m.Amount == other.Amount
+}
+
+// Add sums two amounts of the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if !m.sameCurrency(other) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Sub subtracts other from m. It refuses to go below zero (ErrNegativeMoney),
+// so a transfer can never overdraw.
+func (m Money) Sub(other Money) (Money, error) {
+	if !m.sameCurrency(other) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	if other.Amount > m.Amount {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrNegativeMoney, m, other)
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+func (m Money) sameCurrency(other Money) bool {
+	return strings.EqualFold(m.Currency, other.Currency)
+}
+
+// AllocateMoney splits total (minor units) into n parts as evenly as possible.
+// Leftover units go one each to the first parts, so the parts always add up
+// to total, ex: 10000 / 3 -> [3334 3333 3333]. n < 1 returns nil.
+func AllocateMoney(total int64, n int) []int64 {
+	if n < 1 {
+		return nil
+	}
+	parts := make([]int64, n)
+	share, rem := total/int64(n), total%int64(n)
+	step := int64(1)
+	if rem < 0 { // negative totals hand out the leftover as -1s
+		step, rem = -1, -rem
+	}
+	for i := range parts {
+		parts[i] = share
+		if int64(i) < rem {
+			parts[i] += step
+		}
+	}
+	return parts
+}
+
+// currencyDecimals - digits after the decimal point per currency (default 2)
+var currencyDecimals = map[string]int{
+	"USD": 2, "EUR": 2, "GBP": 2, "INR": 2,
+	"JPY": 0, "KRW": 0,
+	"BHD": 3, "KWD": 3, "OMR": 3,
+}
+
+// decimalsFor returns the currency's decimal places, falling back to 2.
+func decimalsFor(currency string) int {
+	if d, ok := currencyDecimals[strings.ToUpper(currency)]; ok {
+		return d
+	}
+	return 2
+}
+
+// FormatMoney formats amount with the right number of decimals for currency,
+// rounding HalfUp first, ex: 1234.5 JPY -> "1235", USD -> "1234.50", BHD -> "1234.500".
+func FormatMoney(amount float64, currency string) string {
+	dec := decimalsFor(currency)
+	return strconv.FormatFloat(roundTo(amount, dec, HalfUp), 'f', dec, 64)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestMoneyAddSub(t *testing.T) {
+	a, b := NewMoney(1050, "usd"), NewMoney(250, "USD")
+
+	sum, err := a.Add(b)
+	if err != nil || !sum.Equal(NewMoney(1300, "USD")) {
+		t.Errorf("Add = %v, %v; want 13.00 USD", sum, err)
+	}
+	diff, err := a.Sub(b)
+	if err != nil || !diff.Equal(NewMoney(800, "USD")) {
+		t.Errorf("Sub = %v, %v; want 8.00 USD", diff, err)
+	}
+	if zero, err := a.Sub(a); err != nil || zero.Amount != 0 {
+		t.Errorf("a - a = %v, %v; want 0", zero, err)
+	}
+}
+
+func TestMoneyCurrencyMismatch(t *testing.T) {
+	usd, eur := NewMoney(100, "USD"), NewMoney(100, "EUR")
+	if _, err := usd.Add(eur); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add err = %v, want ErrCurrencyMismatch", err)
+	}
+	if _, err := usd.Sub(eur); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Sub err = %v, want ErrCurrencyMismatch", err)
+	}
+	if usd.Equal(eur) {
+		t.Error("USD and EUR amounts should not be Equal")
+	}
+}
+
+func TestMoneySubNegative(t *testing.T) {
+	_, err := NewMoney(100, "USD").Sub(NewMoney(101, "USD"))
+	if !errors.Is(err, ErrNegativeMoney) {
+		t.Errorf("err = %v, want ErrNegativeMoney", err)
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{NewMoney(123456, "USD"), "1234.56 USD"},
+		{NewMoney(-5, "USD"), "-0.05 USD"},
+		{NewMoney(1234, "JPY"), "1234 JPY"},
+		{NewMoney(1234500, "BHD"), "1234.500 BHD"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestAllocateMoney(t *testing.T) {
+	tests := []struct {
+		total int64
+		n     int
+		want  []int64
+	}{
+		{10000, 3, []int64{3334, 3333, 3333}},
+		{100, 4, []int64{25, 25, 25, 25}},
+		{5, 7, []int64{1, 1, 1, 1, 1, 0, 0}},
+		{-100, 3, []int64{-34, -33, -33}},
+		{0, 2, []int64{0, 0}},
+		{100, 0, nil},
+	}
+	for _, tt := range tests {
+		got := AllocateMoney(tt.total, tt.n)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("AllocateMoney(%d, %d) = %v, want %v", tt.total, tt.n, got, tt.want)
+		}
+		var sum int64
+		for _, p := range got {
+			sum += p
+		}
+		if tt.n > 0 && sum != tt.total {
+			t.Errorf("AllocateMoney(%d, %d) parts sum to %d", tt.total, tt.n, sum)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     string
+	}{
+		{1234.5, "JPY", "1235"},
+		{1234.4, "jpy", "1234"},
+		{1234.5, "USD", "1234.50"},
+		{0.125, "USD", "0.13"},
+		{1.005, "EUR", "1.01"},
+		{-0.125, "USD", "-0.13"},
+		{1234.5, "BHD", "1234.500"},
+		{0.0005, "KWD", "0.001"},
+		{2.345, "XYZ", "2.35"}, // unknown currency -> 2 decimals
+	}
+	for _, tt := range tests {
+		if got := FormatMoney(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("FormatMoney(%v, %q) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
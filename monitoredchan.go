@@ -0,0 +1,48 @@
+package main
+
+import "sync/atomic"
+
+// MonitoredChan wraps a buffered channel and remembers the deepest queue
+// it has seen, which helps when tuning the buffer size 📈
+type MonitoredChan[T any] struct {
+	ch        chan T
+	highWater atomic.Int64
+}
+
+func NewMonitoredChan[T any](capacity int) *MonitoredChan[T] {
+	return &MonitoredChan[T]{ch: make(chan T, capacity)}
+}
+
+// Send pushes v (blocking when the buffer is full) and updates the high-water mark.
+func (m *MonitoredChan[T]) Send(v T) {
+	m.ch <- v
+	depth := int64(len(m.ch))
+	for {
+		cur := m.highWater.Load()
+		if depth <= cur || m.highWater.CompareAndSwap(cur, depth) {
+			return
+		}
+	}
+}
+
+// C is the receive side - consumers drain it like a normal channel.
+func (m *MonitoredChan[T]) C() <-chan T {
+	return m.ch
+}
+
+func (m *MonitoredChan[T]) Close() {
+	close(m.ch)
+}
+
+func (m *MonitoredChan[T]) Len() int {
+	return len(m.ch)
+}
+
+func (m *MonitoredChan[T]) Cap() int {
+	return cap(m.ch)
+}
+
+// HighWaterMark is the maximum queue depth observed right after a Send.
+func (m *MonitoredChan[T]) HighWaterMark() int {
+	return int(m.highWater.Load())
+}
@@ -0,0 +1,29 @@
+package main
+
+import "testing"
+
+func TestMonitoredChanHighWaterMark(t *testing.T) {
+	m := NewMonitoredChan[string](5)
+	for _, v := range []string{"a", "b", "c", "d", "e"} {
+		m.Send(v)
+	}
+	if m.Len() != 5 || m.Cap() != 5 {
+		t.Fatalf("Len/Cap = %d/%d, want 5/5", m.Len(), m.Cap())
+	}
+
+	<-m.C()
+	<-m.C()
+	m.Send("f")
+	m.Close()
+
+	var drained []string
+	for v := range m.C() {
+		drained = append(drained, v)
+	}
+	if len(drained) != 4 {
+		t.Errorf("drained %v, want 4 values", drained)
+	}
+	if got := m.HighWaterMark(); got != 5 {
+		t.Errorf("HighWaterMark() = %d, want 5", got)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Notifier sends a message to someone - email, SMS, whatever 🔔
+type Notifier interface {
+	Send(to, message string) error
+}
+
+// EmailNotifier simulates sending an email (no real SMTP here)
+type EmailNotifier struct {
+	Delay time.Duration // simulated network latency
+}
+
+func (e EmailNotifier) Send(to, message string) error {
+	if to == "" {
+		return errors.New("email: missing recipient")
+	}
+	time.Sleep(e.Delay)
+	fmt.Printf("📧 Email to %s: %s\n", to, message)
+	return nil
+}
+
+// SMSNotifier is a stub that just prints the text message
+type SMSNotifier struct{}
+
+func (SMSNotifier) Send(to, message string) error {
+	if to == "" {
+		return errors.New("sms: missing recipient")
+	}
+	fmt.Printf("📱 SMS to %s: %s\n", to, message)
+	return nil
+}
+
+// MultiNotifier fans a message out to every notifier in it.
+// All of them are tried; failures are joined into one error.
+type MultiNotifier []Notifier
+
+func (m MultiNotifier) Send(to, message string) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Send(to, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
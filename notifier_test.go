@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+// recordingNotifier remembers every message instead of sending it
+type recordingNotifier struct {
+	sent []string
+	err  error
+}
+
+func (r *recordingNotifier) Send(to, message string) error {
+	r.sent = append(r.sent, to+": "+message)
+	return r.err
+}
+
+func TestMultiNotifierFansOut(t *testing.T) {
+	a, b := &recordingNotifier{}, &recordingNotifier{}
+	m := MultiNotifier{a, b}
+
+	if err := m.Send("alice", "large withdrawal"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	for i, r := range []*recordingNotifier{a, b} {
+		if len(r.sent) != 1 || r.sent[0] != "alice: large withdrawal" {
+			t.Errorf("notifier %d got %v", i, r.sent)
+		}
+	}
+}
+
+func TestMultiNotifierJoinsErrors(t *testing.T) {
+	errA, errB := errors.New("a down"), errors.New("b down")
+	a := &recordingNotifier{err: errA}
+	ok := &recordingNotifier{}
+	b := &recordingNotifier{err: errB}
+
+	err := MultiNotifier{a, ok, b}.Send("bob", "hi")
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("err = %v, want both failures joined", err)
+	}
+	if len(ok.sent) != 1 {
+		t.Error("a failing notifier should not stop the others")
+	}
+}
+
+func TestNotifierMissingRecipient(t *testing.T) {
+	for _, n := range []Notifier{EmailNotifier{}, SMSNotifier{}} {
+		if err := n.Send("", "hi"); err == nil {
+			t.Errorf("%T.Send with no recipient should fail", n)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import "container/heap"
+
+// PriorityQueue pops elements in the order given by less
+// (less(a, b) == a < b gives a min-heap, a > b gives a max-heap) ⛰️
+type PriorityQueue[T any] struct {
+	h *heapSlice[T]
+}
+
+func NewPriorityQueue[T any](less func(a, b T) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{h: &heapSlice[T]{less: less}}
+}
+
+func (pq *PriorityQueue[T]) Push(v T) {
+	heap.Push(pq.h, v)
+}
+
+// Pop removes the highest-priority element, or returns false if empty.
+func (pq *PriorityQueue[T]) Pop() (T, bool) {
+	if pq.h.Len() == 0 {
+		var zero T
+		return zero, false
+	}
+	return heap.Pop(pq.h).(T), true
+}
+
+func (pq *PriorityQueue[T]) Len() int {
+	return pq.h.Len()
+}
+
+// heapSlice implements heap.Interface for PriorityQueue
+type heapSlice[T any] struct {
+	items []T
+	less  func(a, b T) bool
+}
+
+func (h *heapSlice[T]) Len() int           { return len(h.items) }
+func (h *heapSlice[T]) Less(i, j int) bool { return h.less(h.items[i], h.items[j]) }
+func (h *heapSlice[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *heapSlice[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+
+func (h *heapSlice[T]) Pop() any {
+	last := len(h.items) - 1
+	v := h.items[last]
+	var zero T
+	h.items[last] = zero // don't keep a reference around
+	h.items = h.items[:last]
+	return v
+}
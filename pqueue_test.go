@@ -0,0 +1,51 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func drainQueue[T any](pq *PriorityQueue[T]) []T {
+	var out []T
+	for {
+		v, ok := pq.Pop()
+		if !ok {
+			return out
+		}
+		out = append(out, v)
+	}
+}
+
+func TestPriorityQueueMinHeap(t *testing.T) {
+	pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+	for _, v := range []int{5, 1, 4, 1, 9, 2, 6} {
+		pq.Push(v)
+	}
+	if pq.Len() != 7 {
+		t.Fatalf("Len() = %d, want 7", pq.Len())
+	}
+
+	got := drainQueue(pq)
+	if want := []int{1, 1, 2, 4, 5, 6, 9}; !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+}
+
+func TestPriorityQueueMaxHeap(t *testing.T) {
+	pq := NewPriorityQueue(func(a, b string) bool { return a > b })
+	for _, v := range []string{"pear", "apple", "fig", "kiwi"} {
+		pq.Push(v)
+	}
+
+	got := drainQueue(pq)
+	if want := []string{"pear", "kiwi", "fig", "apple"}; !slices.Equal(got, want) {
+		t.Errorf("popped %v, want %v", got, want)
+	}
+}
+
+func TestPriorityQueueEmpty(t *testing.T) {
+	pq := NewPriorityQueue(func(a, b int) bool { return a < b })
+	if v, ok := pq.Pop(); ok || v != 0 {
+		t.Errorf("Pop() on empty = %d, %v; want 0, false", v, ok)
+	}
+}
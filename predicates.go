@@ -0,0 +1,34 @@
+package main
+
+// Predicate combinators 🧩 - build filters out of small func(T) bool pieces.
+
+// And is true when every predicate is true (true for no predicates).
+func And[T any](preds ...func(T) bool) func(T) bool {
+	return func(v T) bool {
+		for _, p := range preds {
+			if !p(v) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or is true when any predicate is true (false for no predicates).
+func Or[T any](preds ...func(T) bool) func(T) bool {
+	return func(v T) bool {
+		for _, p := range preds {
+			if p(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not flips a predicate.
+func Not[T any](pred func(T) bool) func(T) bool {
+	return func(v T) bool {
+		return !pred(v)
+	}
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+type searchOrder struct {
+	id     string
+	status string
+	amount float64
+}
+
+func filterOrders(orders []searchOrder, keep func(searchOrder) bool) []string {
+	var ids []string
+	for _, o := range orders {
+		if keep(o) {
+			ids = append(ids, o.id)
+		}
+	}
+	return ids
+}
+
+func TestPredicateCombinators(t *testing.T) {
+	orders := []searchOrder{
+		{"1", "shipped", 150},
+		{"2", "shipped", 50},
+		{"3", "pending", 500},
+		{"4", "cancelled", 120},
+	}
+	shipped := func(o searchOrder) bool { return o.status == "shipped" }
+	big := func(o searchOrder) bool { return o.amount > 100 }
+	cancelled := func(o searchOrder) bool { return o.status == "cancelled" }
+
+	tests := []struct {
+		name string
+		pred func(searchOrder) bool
+		want []string
+	}{
+		{"shipped AND big", And(shipped, big), []string{"1"}},
+		{"shipped OR big", Or(shipped, big), []string{"1", "2", "3", "4"}},
+		{"big AND NOT cancelled", And(big, Not(cancelled)), []string{"1", "3"}},
+		{"NOT (shipped OR cancelled)", Not(Or(shipped, cancelled)), []string{"3"}},
+		{"empty And", And[searchOrder](), []string{"1", "2", "3", "4"}},
+		{"empty Or", Or[searchOrder](), nil},
+	}
+	for _, tt := range tests {
+		if got := filterOrders(orders, tt.pred); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
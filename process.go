@@ -0,0 +1,36 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Process runs fn over items in order and stops at the first error,
+// returning the results so far together with that error.
+func Process[T, R any](items []T, fn func(T) (R, error)) ([]R, error) {
+	results := make([]R, 0, len(items))
+	for i, item := range items {
+		r, err := fn(item)
+		if err != nil {
+			return results, fmt.Errorf("item %d: %w", i, err)
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// ProcessAll is the continue-on-error variant: it processes every item,
+// keeps the successful results and returns all failures joined into one error.
+func ProcessAll[T, R any](items []T, fn func(T) (R, error)) ([]R, error) {
+	results := make([]R, 0, len(items))
+	var errs []error
+	for i, item := range items {
+		r, err := fn(item)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("item %d: %w", i, err))
+			continue
+		}
+		results = append(results, r)
+	}
+	return results, errors.Join(errs...)
+}
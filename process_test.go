@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var processInputs = []string{"1", "2", "x", "4", "y"}
+
+func TestProcessStopsAtFirstError(t *testing.T) {
+	calls := 0
+	got, err := Process(processInputs, func(s string) (int, error) {
+		calls++
+		return strconv.Atoi(s)
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "item 2") {
+		t.Fatalf("err = %v, want an error naming item 2", err)
+	}
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Errorf("err = %v should wrap the *strconv.NumError", err)
+	}
+	if !slices.Equal(got, []int{1, 2}) || calls != 3 {
+		t.Errorf("results = %v after %d calls, want [1 2] after 3", got, calls)
+	}
+}
+
+func TestProcessAllContinues(t *testing.T) {
+	got, err := ProcessAll(processInputs, strconv.Atoi)
+	if !slices.Equal(got, []int{1, 2, 4}) {
+		t.Errorf("results = %v, want [1 2 4]", got)
+	}
+	if err == nil || !strings.Contains(err.Error(), "item 2") || !strings.Contains(err.Error(), "item 4") {
+		t.Errorf("err = %v, want both failures", err)
+	}
+
+	if got, err := ProcessAll([]string{"7"}, strconv.Atoi); err != nil || !slices.Equal(got, []int{7}) {
+		t.Errorf("ProcessAll = %v, %v; want [7], nil", got, err)
+	}
+}
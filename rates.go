@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ratesClient fetches exchange rates over HTTP and caches them for ttl 💱
+// The endpoint is expected to return JSON like:
+//
+//	{"base": "USD", "rates": {"EUR": 0.92, "INR": 83.1}}
+type ratesClient struct {
+	url        string
+	ttl        time.Duration
+	retries    int           // extra attempts after the first one
+	retryBase  time.Duration // backoff before the 1st retry (doubles after that, jittered)
+	retryMax   time.Duration // cap on the backoff
+	httpClient *http.Client
+
+	mu        sync.Mutex
+	rates     map[string]float64 // units of currency per 1 base unit
+	fetchedAt time.Time
+}
+
+func newRatesClient(url string, ttl time.Duration) *ratesClient {
+	return &ratesClient{
+		url:        url,
+		ttl:        ttl,
+		retries:    2,
+		retryBase:  200 * time.Millisecond,
+		retryMax:   2 * time.Second,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+type ratesResponse struct {
+	Base  string             `json:"base"`
+	Rates map[string]float64 `json:"rates"`
+}
+
+// Convert converts amount from one currency to another using the cached rates.
+func (c *ratesClient) Convert(amount float64, from, to string) (float64, error) {
+	rates, err := c.getRates()
+	if err != nil {
+		return 0, err
+	}
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	fromRate, ok := rates[from]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency %q", from)
+	}
+	toRate, ok := rates[to]
+	if !ok {
+		return 0, fmt.Errorf("unknown currency %q", to)
+	}
+	return amount / fromRate * toRate, nil
+}
+
+// getRates returns the cached rates, refreshing them once the ttl has passed.
+func (c *ratesClient) getRates() (map[string]float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rates != nil && time.Since(c.fetchedAt) < c.ttl {
+		return c.rates, nil
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(BackoffWithJitter(attempt-1, c.retryBase, c.retryMax))
+		}
+		rates, retryable, err := c.fetch()
+		if err == nil {
+			c.rates, c.fetchedAt = rates, time.Now()
+			return rates, nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+	return nil, fmt.Errorf("fetching exchange rates: %w", lastErr)
+}
+
+// fetch does one HTTP round-trip. retryable reports whether trying again might help.
+func (c *ratesClient) fetch() (rates map[string]float64, retryable bool, err error) {
+	resp, err := c.httpClient.Get(c.url)
+	if err != nil {
+		return nil, true, err // network trouble - transient
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		return nil, true, fmt.Errorf("rates server returned %s", resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("rates server returned %s", resp.Status)
+	}
+
+	var body ratesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, false, fmt.Errorf("decoding rates: %w", err)
+	}
+	rates = make(map[string]float64, len(body.Rates)+1)
+	for cur, r := range body.Rates {
+		if r <= 0 {
+			return nil, false, fmt.Errorf("invalid rate %v for %s", r, cur)
+		}
+		rates[strings.ToUpper(cur)] = r
+	}
+	if body.Base != "" {
+		rates[strings.ToUpper(body.Base)] = 1
+	}
+	return rates, false, nil
+}
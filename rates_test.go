@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestRatesClient points a client at an httptest server with fast retries.
+func newTestRatesClient(t *testing.T, handler http.HandlerFunc) (*ratesClient, *atomic.Int32) {
+	t.Helper()
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		handler(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	c := newRatesClient(srv.URL, time.Minute)
+	c.retryBase, c.retryMax = time.Millisecond, time.Millisecond
+	return c, &hits
+}
+
+func cannedRates(w http.ResponseWriter, _ *http.Request) {
+	fmt.Fprint(w, `{"base": "USD", "rates": {"EUR": 0.5, "inr": 80}}`)
+}
+
+func TestRatesConvertAndCache(t *testing.T) {
+	c, hits := newTestRatesClient(t, cannedRates)
+
+	tests := []struct {
+		amount   float64
+		from, to string
+		want     float64
+	}{
+		{10, "USD", "EUR", 5},
+		{10, "eur", "usd", 20},
+		{1, "EUR", "INR", 160},
+	}
+	for _, tt := range tests {
+		got, err := c.Convert(tt.amount, tt.from, tt.to)
+		if err != nil {
+			t.Fatalf("Convert(%v, %s, %s): %v", tt.amount, tt.from, tt.to, err)
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Convert(%v, %s, %s) = %v, want %v", tt.amount, tt.from, tt.to, got, tt.want)
+		}
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server hit %d times, want 1 (cached)", n)
+	}
+}
+
+func TestRatesCacheExpires(t *testing.T) {
+	c, hits := newTestRatesClient(t, cannedRates)
+	c.ttl = 0
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Convert(1, "USD", "EUR"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := hits.Load(); n != 2 {
+		t.Errorf("server hit %d times, want 2 after expiry", n)
+	}
+}
+
+func TestRatesUnknownCurrency(t *testing.T) {
+	c, _ := newTestRatesClient(t, cannedRates)
+	if _, err := c.Convert(1, "USD", "XYZ"); err == nil {
+		t.Error("Convert to unknown currency should fail")
+	}
+}
+
+func TestRatesRetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	c, hits := newTestRatesClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "busy", http.StatusServiceUnavailable)
+			return
+		}
+		cannedRates(w, r)
+	})
+
+	if _, err := c.Convert(1, "USD", "EUR"); err != nil {
+		t.Fatalf("Convert after 2 transient failures: %v", err)
+	}
+	if n := hits.Load(); n != 3 {
+		t.Errorf("server hit %d times, want 3", n)
+	}
+}
+
+func TestRatesNoRetryOnClientError(t *testing.T) {
+	c, hits := newTestRatesClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.NotFound(w, nil)
+	})
+
+	if _, err := c.Convert(1, "USD", "EUR"); err == nil {
+		t.Fatal("Convert should fail on 404")
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("server hit %d times, want 1 (404 is not retried)", n)
+	}
+}
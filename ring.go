@@ -0,0 +1,58 @@
+package main
+
+// Ring is a fixed-capacity circular buffer - once full, Push overwrites the oldest value 🔁
+type Ring[T any] struct {
+	buf   []T
+	start int // index of the oldest element
+	size  int
+}
+
+// NewRing creates a ring holding at most capacity elements (minimum 1).
+func NewRing[T any](capacity int) *Ring[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Ring[T]{buf: make([]T, capacity)}
+}
+
+func (r *Ring[T]) Push(v T) {
+	if r.size < len(r.buf) {
+		r.buf[(r.start+r.size)%len(r.buf)] = v
+		r.size++
+		return
+	}
+	// full: overwrite the oldest and move start forward
+	r.buf[r.start] = v
+	r.start = (r.start + 1) % len(r.buf)
+}
+
+// Slice returns the current contents, oldest first.
+func (r *Ring[T]) Slice() []T {
+	out := make([]T, r.size)
+	for i := range out {
+		out[i] = r.buf[(r.start+i)%len(r.buf)]
+	}
+	return out
+}
+
+func (r *Ring[T]) Len() int {
+	return r.size
+}
+
+func (r *Ring[T]) Cap() int {
+	return len(r.buf)
+}
+
+// MovingAverage returns a closure that records a sample and returns the
+// average of the last window samples (or of all samples, before the window fills).
+func MovingAverage(window int) func(x float64) float64 {
+	samples := NewRing[float64](window)
+	return func(x float64) float64 {
+		samples.Push(x)
+		sum := 0.0
+		for _, v := range samples.Slice() {
+			sum += v
+		}
+		return sum / float64(samples.Len())
+	}
+}
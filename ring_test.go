@@ -0,0 +1,55 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRingNotFull(t *testing.T) {
+	r := NewRing[int](4)
+	r.Push(1)
+	r.Push(2)
+
+	if got := r.Slice(); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Slice() = %v, want [1 2]", got)
+	}
+	if r.Len() != 2 || r.Cap() != 4 {
+		t.Errorf("Len/Cap = %d/%d, want 2/4", r.Len(), r.Cap())
+	}
+	if got := NewRing[int](3).Slice(); len(got) != 0 {
+		t.Errorf("empty ring Slice() = %v", got)
+	}
+}
+
+func TestRingWrapAround(t *testing.T) {
+	r := NewRing[int](3)
+	for i := 1; i <= 7; i++ {
+		r.Push(i)
+	}
+
+	if got := r.Slice(); !slices.Equal(got, []int{5, 6, 7}) {
+		t.Errorf("Slice() = %v, want [5 6 7]", got)
+	}
+	if r.Len() != 3 {
+		t.Errorf("Len() = %d, want 3", r.Len())
+	}
+}
+
+func TestRingMinimumCapacity(t *testing.T) {
+	r := NewRing[string](0)
+	r.Push("a")
+	r.Push("b")
+	if got := r.Slice(); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("Slice() = %v, want [b]", got)
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	avg := MovingAverage(3)
+	want := []float64{2, 3, 4, 6, 8}
+	for i, x := range []float64{2, 4, 6, 8, 10} {
+		if got := avg(x); got != want[i] {
+			t.Errorf("sample %d: avg = %v, want %v", i, got, want[i])
+		}
+	}
+}
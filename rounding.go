@@ -0,0 +1,78 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// RoundingMode - how to round money to 2 decimals 🎯
+type RoundingMode int
+
+const (
+	HalfUp   RoundingMode = iota // 0.005 -> 0.01 (ties away from zero)
+	HalfEven                     // 0.005 -> 0.00, 0.015 -> 0.02 (banker's rounding)
+	Down                         // 0.009 -> 0.00 (truncate toward zero)
+)
+
+func (m RoundingMode) String() string {
+	switch m {
+	case HalfUp:
+		return "HalfUp"
+	case HalfEven:
+		return "HalfEven"
+	case Down:
+		return "Down"
+	default:
+		return fmt.Sprintf("RoundingMode(%d)", int(m))
+	}
+}
+
+// roundingEps absorbs float noise, ex: 1.005 is really stored as 1.00499999999999989..
+const roundingEps = 1e-6
+
+// RoundMoney rounds amount to 2 decimal places using mode.
+func RoundMoney(amount float64, mode RoundingMode) float64 {
+	return roundTo(amount, 2, mode)
+}
+
+// roundTo rounds amount to the given number of decimal places using mode,
+// for currencies that don't use 2 (see decimalsFor).
+func roundTo(amount float64, decimals int, mode RoundingMode) float64 {
+	if math.IsNaN(amount) || math.IsInf(amount, 0) {
+		return amount
+	}
+	sign := 1.0
+	if amount < 0 {
+		sign, amount = -1, -amount
+	}
+
+	scale := math.Pow10(decimals)
+	units := amount * scale
+	whole := math.Floor(units)
+	frac := units - whole
+
+	switch {
+	case frac > 1-roundingEps: // ex: 2.9999999 is really 3
+		whole++
+	case frac < roundingEps: // already exact
+	case mode == Down:
+	case math.Abs(frac-0.5) < roundingEps: // exactly on the .5 boundary
+		if mode == HalfUp || math.Mod(whole, 2) == 1 {
+			whole++
+		}
+	case frac > 0.5:
+		whole++
+	}
+	if whole == 0 {
+		return 0 // not -0, which formats as "-0.00"
+	}
+	return sign * whole / scale
+}
+
+// formatCents renders amount with exactly 2 decimals after rounding it HalfUp,
+// so stored & exported amounts don't depend on %.2f's binary-float rounding
+// (fmt.Sprintf("%.2f", 1.005) gives "1.00").
+func formatCents(amount float64) string {
+	return strconv.FormatFloat(RoundMoney(amount, HalfUp), 'f', 2, 64)
+}
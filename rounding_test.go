@@ -0,0 +1,59 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRoundMoneyBoundary(t *testing.T) {
+	tests := []struct {
+		amount                 float64
+		halfUp, halfEven, down float64
+	}{
+		{0.005, 0.01, 0.00, 0.00},
+		{0.015, 0.02, 0.02, 0.01},
+		{0.025, 0.03, 0.02, 0.02},
+		{1.005, 1.01, 1.00, 1.00}, // stored as 1.00499999..
+		{2.675, 2.68, 2.68, 2.67},
+		{-0.005, -0.01, 0.00, 0.00},
+		{-1.015, -1.02, -1.02, -1.01},
+		{0.0049, 0.00, 0.00, 0.00},
+		{0.0051, 0.01, 0.01, 0.00},
+		{0.009, 0.01, 0.01, 0.00},
+		{12.34, 12.34, 12.34, 12.34},
+	}
+	for _, tt := range tests {
+		for mode, want := range map[RoundingMode]float64{HalfUp: tt.halfUp, HalfEven: tt.halfEven, Down: tt.down} {
+			if got := RoundMoney(tt.amount, mode); got != want {
+				t.Errorf("RoundMoney(%v, %v) = %v, want %v", tt.amount, mode, got, want)
+			}
+		}
+	}
+}
+
+func TestRoundMoneyNonFinite(t *testing.T) {
+	if got := RoundMoney(math.Inf(1), HalfUp); !math.IsInf(got, 1) {
+		t.Errorf("RoundMoney(+Inf) = %v", got)
+	}
+	if got := RoundMoney(math.NaN(), Down); !math.IsNaN(got) {
+		t.Errorf("RoundMoney(NaN) = %v", got)
+	}
+}
+
+func TestFormatCents(t *testing.T) {
+	tests := []struct {
+		amount float64
+		want   string
+	}{
+		{1.005, "1.01"},
+		{1000, "1000.00"},
+		{0.125, "0.13"},
+		{-25.5, "-25.50"},
+		{-1e-14, "0.00"}, // float noise, never "-0.00"
+	}
+	for _, tt := range tests {
+		if got := formatCents(tt.amount); got != tt.want {
+			t.Errorf("formatCents(%v) = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
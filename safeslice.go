@@ -0,0 +1,71 @@
+package main
+
+// SafeSlice wraps a normal slice with bounds-checked access -
+// out-of-range indices return false instead of panicking 🛡️
+type SafeSlice[T any] struct {
+	items []T
+}
+
+// NewSafeSlice wraps items (the slice is used as-is, not copied).
+func NewSafeSlice[T any](items ...T) *SafeSlice[T] {
+	return &SafeSlice[T]{items: items}
+}
+
+func (s *SafeSlice[T]) inRange(i int) bool {
+	return i >= 0 && i < len(s.items)
+}
+
+// At returns the element at i, or the zero value and false if i is out of range.
+func (s *SafeSlice[T]) At(i int) (T, bool) {
+	if !s.inRange(i) {
+		var zero T
+		return zero, false
+	}
+	return s.items[i], true
+}
+
+// Set replaces the element at i, reporting false if i is out of range.
+func (s *SafeSlice[T]) Set(i int, v T) bool {
+	if !s.inRange(i) {
+		return false
+	}
+	s.items[i] = v
+	return true
+}
+
+// Append adds values to the end.
+func (s *SafeSlice[T]) Append(vals ...T) {
+	s.items = append(s.items, vals...)
+}
+
+func (s *SafeSlice[T]) Len() int {
+	return len(s.items)
+}
+
+// Slice exposes the underlying slice.
+func (s *SafeSlice[T]) Slice() []T {
+	return s.items
+}
+
+// First returns the first element, or false for an empty slice.
+func First[T any](s []T) (T, bool) {
+	return Nth(s, 0)
+}
+
+// Last returns the last element, or false for an empty slice.
+func Last[T any](s []T) (T, bool) {
+	return Nth(s, -1)
+}
+
+// Nth returns s[i] without panicking. Negative indices count from the end
+// like Python (-1 is the last element); out-of-range gives false.
+func Nth[T any](s []T, i int) (T, bool) {
+	if i < 0 {
+		i += len(s)
+	}
+	if i < 0 || i >= len(s) {
+		var zero T
+		return zero, false
+	}
+	return s[i], true
+}
@@ -0,0 +1,66 @@
+package main
+
+import "testing"
+
+func TestSafeSliceAt(t *testing.T) {
+	s := NewSafeSlice("a", "b", "c")
+
+	for _, i := range []int{-1, -100, 3, 100} {
+		if v, ok := s.At(i); ok || v != "" {
+			t.Errorf("At(%d) = %q, %v; want zero, false", i, v, ok)
+		}
+	}
+	if v, ok := s.At(2); !ok || v != "c" {
+		t.Errorf("At(2) = %q, %v; want c, true", v, ok)
+	}
+}
+
+func TestSafeSliceSetAppend(t *testing.T) {
+	s := NewSafeSlice[int]()
+	if s.Set(0, 1) {
+		t.Error("Set on empty slice should fail")
+	}
+
+	s.Append(1, 2)
+	if !s.Set(1, 20) {
+		t.Error("Set(1) should succeed")
+	}
+	if s.Set(-1, 0) || s.Set(2, 0) {
+		t.Error("Set out of range should fail")
+	}
+	if got := s.Slice(); len(got) != 2 || got[0] != 1 || got[1] != 20 {
+		t.Errorf("Slice() = %v, want [1 20]", got)
+	}
+	if s.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", s.Len())
+	}
+}
+
+func TestNth(t *testing.T) {
+	s := []int{10, 20, 30}
+	tests := []struct {
+		i      int
+		want   int
+		wantOK bool
+	}{
+		{0, 10, true},
+		{2, 30, true},
+		{-1, 30, true},
+		{-3, 10, true},
+		{3, 0, false},
+		{-4, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := Nth(s, tt.i)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Nth(%d) = %d, %v; want %d, %v", tt.i, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if _, ok := First([]int{}); ok {
+		t.Error("First(empty) should be false")
+	}
+	if v, ok := Last(s); !ok || v != 30 {
+		t.Errorf("Last = %d, %v; want 30, true", v, ok)
+	}
+}
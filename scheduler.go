@@ -0,0 +1,38 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+)
+
+// Scheduler produces pseudo-random ints in [0, max) from the *rand.Rand it's given -
+// seed that with a fixed value and the sequence is reproducible 🎲
+type Scheduler struct {
+	rng *rand.Rand
+	max int
+}
+
+func NewScheduler(rng *rand.Rand, max int) *Scheduler {
+	if max < 1 {
+		max = 1
+	}
+	return &Scheduler{rng: rng, max: max}
+}
+
+// Run sends values until ctx is cancelled, then closes the channel.
+// Only one Run should be active at a time (*rand.Rand isn't goroutine-safe).
+func (s *Scheduler) Run(ctx context.Context) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		for {
+			n := s.rng.Intn(s.max)
+			select {
+			case <-ctx.Done():
+				return
+			case out <- n:
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+	"time"
+)
+
+func take(ch <-chan int, n int) []int {
+	out := make([]int, 0, n)
+	for v := range ch {
+		out = append(out, v)
+		if len(out) == n {
+			break
+		}
+	}
+	return out
+}
+
+func TestSchedulerFixedSeed(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := NewScheduler(rand.New(rand.NewSource(42)), 100)
+	got := take(s.Run(ctx), 8)
+	if want := []int{5, 87, 68, 50, 23, 45, 57, 76}; !slices.Equal(got, want) {
+		t.Errorf("sequence = %v, want %v", got, want)
+	}
+}
+
+func TestSchedulerStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := NewScheduler(rand.New(rand.NewSource(1)), 10).Run(ctx)
+	take(ch, 3)
+	cancel()
+
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("channel not closed after cancel")
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Group coalesces concurrent calls that share a key - the function runs once
+// and every caller waiting on that key gets the same result (singleflight style) ✈️
+type Group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// call is one in-flight (or just finished) Do invocation
+type call struct {
+	done chan struct{}
+	val  any
+	err  error
+}
+
+// Do runs fn for key unless a call for key is already running,
+// in which case it waits for that call and returns its result.
+func (g *Group) Do(key string, fn func() (any, error)) (any, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*call{}
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-c.done
+		return c.val, c.err
+	}
+	c := &call{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	// clean up even if fn panics, so waiters aren't stuck forever.
+	// Waiters get an error instead of (nil, nil); the panic continues in the leader.
+	defer func() {
+		r := recover()
+		if r != nil {
+			c.err = fmt.Errorf("singleflight: fn panicked: %v", r)
+		}
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(c.done)
+		if r != nil {
+			panic(r)
+		}
+	}()
+	c.val, c.err = fn()
+	return c.val, c.err
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGroupDoCoalesces(t *testing.T) {
+	var g Group
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	const n = 20
+	var wg sync.WaitGroup
+	results := make([]any, n)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = g.Do("rates", func() (any, error) {
+				calls.Add(1)
+				<-release
+				return 42, nil
+			})
+		}()
+	}
+
+	// give every goroutine time to join the in-flight call
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fn ran %d times, want 1", got)
+	}
+	for i, v := range results {
+		if v != 42 {
+			t.Errorf("caller %d got %v, want 42", i, v)
+		}
+	}
+}
+
+func TestGroupDoSharesError(t *testing.T) {
+	var g Group
+	boom := errors.New("boom")
+	if _, err := g.Do("k", func() (any, error) { return nil, boom }); !errors.Is(err, boom) {
+		t.Errorf("err = %v, want %v", err, boom)
+	}
+	// the key is forgotten once the call finishes
+	if v, err := g.Do("k", func() (any, error) { return "again", nil }); err != nil || v != "again" {
+		t.Errorf("second Do = %v, %v; want again, nil", v, err)
+	}
+}
+
+func TestGroupDoPanic(t *testing.T) {
+	var g Group
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	leaderPanic := make(chan any, 1)
+	go func() {
+		defer func() { leaderPanic <- recover() }()
+		g.Do("k", func() (any, error) {
+			close(started)
+			<-release
+			panic("kaboom")
+		})
+	}()
+
+	<-started
+	waiterErr := make(chan error, 1)
+	go func() {
+		_, err := g.Do("k", func() (any, error) { return "not me", nil })
+		waiterErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond) // let the waiter join the in-flight call
+	close(release)
+
+	if r := <-leaderPanic; r != "kaboom" {
+		t.Errorf("leader recovered %v, want the original panic", r)
+	}
+	err := <-waiterErr
+	if err == nil || !strings.Contains(err.Error(), "kaboom") {
+		t.Errorf("waiter err = %v, want panic error", err)
+	}
+}
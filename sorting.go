@@ -0,0 +1,16 @@
+package main
+
+import (
+	"cmp"
+	"sort"
+)
+
+// SortBy sorts s in place using less (a thin generic wrapper over sort.Slice).
+func SortBy[T any](s []T, less func(a, b T) bool) {
+	sort.Slice(s, func(i, j int) bool { return less(s[i], s[j]) })
+}
+
+// SortByKey sorts s in place, ascending by the key extracted from each element.
+func SortByKey[T any, K cmp.Ordered](s []T, key func(T) K) {
+	sort.Slice(s, func(i, j int) bool { return key(s[i]) < key(s[j]) })
+}
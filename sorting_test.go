@@ -0,0 +1,58 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+// order is a stand-in for the orders module's type
+type order struct {
+	id        string
+	amount    float32
+	createdAt time.Time
+}
+
+func sampleOrders() []order {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []order{
+		{"b", 20, base.Add(2 * time.Hour)},
+		{"a", 5.5, base.Add(3 * time.Hour)},
+		{"c", 99, base},
+		{"d", 12, base.Add(time.Hour)},
+	}
+}
+
+func orderIDs(orders []order) []string {
+	ids := make([]string, len(orders))
+	for i, o := range orders {
+		ids[i] = o.id
+	}
+	return ids
+}
+
+func TestSortByAmount(t *testing.T) {
+	orders := sampleOrders()
+	SortBy(orders, func(a, b order) bool { return a.amount < b.amount })
+	if got, want := orderIDs(orders), []string{"a", "d", "b", "c"}; !slices.Equal(got, want) {
+		t.Errorf("ascending = %v, want %v", got, want)
+	}
+
+	SortBy(orders, func(a, b order) bool { return a.amount > b.amount })
+	if got, want := orderIDs(orders), []string{"c", "b", "d", "a"}; !slices.Equal(got, want) {
+		t.Errorf("descending = %v, want %v", got, want)
+	}
+}
+
+func TestSortByKey(t *testing.T) {
+	orders := sampleOrders()
+	SortByKey(orders, func(o order) int64 { return o.createdAt.Unix() })
+	if got, want := orderIDs(orders), []string{"c", "d", "b", "a"}; !slices.Equal(got, want) {
+		t.Errorf("by createdAt = %v, want %v", got, want)
+	}
+
+	SortByKey(orders, func(o order) string { return o.id })
+	if got, want := orderIDs(orders), []string{"a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Errorf("by id = %v, want %v", got, want)
+	}
+}
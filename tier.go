@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AccountTier - enum (iota) for the interest tier of an account 🥉🥈🥇
+type AccountTier int
+
+const (
+	Bronze AccountTier = iota
+	Silver
+	Gold
+)
+
+// Rate is the yearly interest rate for the tier (0.04 == 4%).
+func (t AccountTier) Rate() float64 {
+	switch t {
+	case Bronze:
+		return 0.01
+	case Silver:
+		return 0.025
+	case Gold:
+		return 0.04
+	default:
+		return 0
+	}
+}
+
+func (t AccountTier) String() string {
+	switch t {
+	case Bronze:
+		return "Bronze"
+	case Silver:
+		return "Silver"
+	case Gold:
+		return "Gold"
+	default:
+		return fmt.Sprintf("AccountTier(%d)", int(t))
+	}
+}
+
+var tierNames = map[string]AccountTier{
+	"bronze": Bronze,
+	"silver": Silver,
+	"gold":   Gold,
+}
+
+// ParseTier is the reverse of String (case-insensitive).
+func ParseTier(s string) (AccountTier, error) {
+	return ParseEnum(strings.ToLower(strings.TrimSpace(s)), tierNames)
+}
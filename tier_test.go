@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAccountTierRate(t *testing.T) {
+	tests := []struct {
+		tier AccountTier
+		want float64
+	}{
+		{Bronze, 0.01},
+		{Silver, 0.025},
+		{Gold, 0.04},
+		{AccountTier(42), 0},
+	}
+	for _, tt := range tests {
+		if got := tt.tier.Rate(); got != tt.want {
+			t.Errorf("%v.Rate() = %v, want %v", tt.tier, got, tt.want)
+		}
+	}
+}
+
+func TestParseTierRoundTrip(t *testing.T) {
+	for _, tier := range []AccountTier{Bronze, Silver, Gold} {
+		got, err := ParseTier(tier.String())
+		if err != nil {
+			t.Fatalf("ParseTier(%q): %v", tier.String(), err)
+		}
+		if got != tier {
+			t.Errorf("ParseTier(%q) = %v, want %v", tier.String(), got, tier)
+		}
+	}
+
+	if got, err := ParseTier("  GOLD "); err != nil || got != Gold {
+		t.Errorf("ParseTier(%q) = %v, %v; want Gold", "  GOLD ", got, err)
+	}
+
+	_, err := ParseTier("platinum")
+	var perr *EnumParseError
+	if !errors.As(err, &perr) {
+		t.Fatalf("ParseTier(platinum) error = %v, want *EnumParseError", err)
+	}
+}
+
+func TestAccountTierString(t *testing.T) {
+	if got := AccountTier(7).String(); got != "AccountTier(7)" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestAccrueInterestUsesTier(t *testing.T) {
+	ctx := context.Background()
+	for _, tier := range []AccountTier{Bronze, Silver, Gold} {
+		acc := NewAccount(1000, nil)
+		acc.SetTier(tier)
+
+		got, err := AccrueInterest(ctx, acc, 365)
+		if err != nil {
+			t.Fatalf("%v: AccrueInterest: %v", tier, err)
+		}
+		want := RoundMoney(tier.Strategy().Accrue(1000, 365), HalfEven)
+		if got != want {
+			t.Errorf("%v: interest = %v, want %v", tier, got, want)
+		}
+		if acc.Balance() != 1000+want {
+			t.Errorf("%v: balance = %v, want %v", tier, acc.Balance(), 1000+want)
+		}
+	}
+}
+
+func TestAccrueInterestPersistFailure(t *testing.T) {
+	boom := errors.New("disk full")
+	acc := NewAccount(1000, func(context.Context, float64) error { return boom })
+	acc.SetTier(Gold)
+
+	if _, err := AccrueInterest(context.Background(), acc, 30); !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want %v", err, boom)
+	}
+	if acc.Balance() != 1000 {
+		t.Errorf("balance changed to %v after failed persist", acc.Balance())
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// bankLogger receives the structured logs of bank operations.
+// Silent by default - swap it for a real handler to see them.
+var bankLogger = slog.New(slog.DiscardHandler)
+
+type traceIDKey struct{} // unexported key type - no collisions with other packages
+
+// WithTraceID returns a copy of ctx carrying the request-scoped trace id.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, id)
+}
+
+// TraceID returns the trace id stored in ctx, if any.
+func TraceID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(traceIDKey{}).(string)
+	return id, ok
+}
+
+// newTraceID makes a random 16-hex-char id (one per request/command).
+func newTraceID() string {
+	b := make([]byte, 8)
+	rand.Read(b) // never returns an error
+	return hex.EncodeToString(b)
+}
+
+// loggerFrom is bankLogger with the ctx's trace id attached to every line.
+func loggerFrom(ctx context.Context) *slog.Logger {
+	if id, ok := TraceID(ctx); ok {
+		return bankLogger.With("trace_id", id)
+	}
+	return bankLogger
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// captureBankLogs points bankLogger at a buffer for the duration of the test.
+func captureBankLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	old := bankLogger
+	bankLogger = slog.New(slog.NewJSONHandler(&buf, nil))
+	t.Cleanup(func() { bankLogger = old })
+	return &buf
+}
+
+func TestTraceIDInEveryLogLine(t *testing.T) {
+	logs := captureBankLogs(t)
+	acc := NewAccount(100, func(context.Context, float64) error { return nil })
+
+	ctx := WithTraceID(context.Background(), "req-42")
+	if err := Deposit(ctx, acc, 10); err != nil {
+		t.Fatal(err)
+	}
+	Withdraw(ctx, acc, 1000) // fails, and that is logged too
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	if len(lines) < 4 {
+		t.Fatalf("got %d log lines, want at least 4:\n%s", len(lines), logs)
+	}
+	for _, line := range lines {
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("bad log line %q: %v", line, err)
+		}
+		if rec["trace_id"] != "req-42" {
+			t.Errorf("line without trace id: %s", line)
+		}
+	}
+}
+
+func TestTraceID(t *testing.T) {
+	if _, ok := TraceID(context.Background()); ok {
+		t.Error("TraceID on a bare context should be false")
+	}
+	id, ok := TraceID(WithTraceID(context.Background(), "abc"))
+	if !ok || id != "abc" {
+		t.Errorf("TraceID = %q, %v; want abc, true", id, ok)
+	}
+	if a, b := newTraceID(), newTraceID(); len(a) != 16 || a == b {
+		t.Errorf("newTraceID gave %q and %q", a, b)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+// Tree is an n-ary tree node - every node can have any number of children 🌳
+type Tree[T any] struct {
+	Value    T
+	Children []*Tree[T]
+}
+
+func NewTree[T any](value T) *Tree[T] {
+	return &Tree[T]{Value: value}
+}
+
+// AddChild appends a child holding value and returns it (so you can keep building below it).
+func (t *Tree[T]) AddChild(value T) *Tree[T] {
+	child := NewTree(value)
+	t.Children = append(t.Children, child)
+	return child
+}
+
+// DFS visits depth-first, pre-order: a node, then each child's subtree left to right.
+func (t *Tree[T]) DFS(visit func(T)) {
+	if t == nil {
+		return
+	}
+	visit(t.Value)
+	for _, c := range t.Children {
+		c.DFS(visit)
+	}
+}
+
+// BFS visits level by level, left to right, using a FIFO queue.
+func (t *Tree[T]) BFS(visit func(T)) {
+	if t == nil {
+		return
+	}
+	queue := []*Tree[T]{t}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:] // dequeue
+		visit(node.Value)
+		queue = append(queue, node.Children...) // enqueue
+	}
+}
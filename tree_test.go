@@ -0,0 +1,49 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+// sampleTree builds
+//
+//	    a
+//	  / | \
+//	 b  c  d
+//	/ \     \
+//	e  f     g
+func sampleTree() *Tree[string] {
+	root := NewTree("a")
+	b := root.AddChild("b")
+	root.AddChild("c")
+	d := root.AddChild("d")
+	b.AddChild("e")
+	b.AddChild("f")
+	d.AddChild("g")
+	return root
+}
+
+func TestTreeTraversals(t *testing.T) {
+	root := sampleTree()
+
+	var dfs, bfs []string
+	root.DFS(func(v string) { dfs = append(dfs, v) })
+	root.BFS(func(v string) { bfs = append(bfs, v) })
+
+	if want := []string{"a", "b", "e", "f", "c", "d", "g"}; !slices.Equal(dfs, want) {
+		t.Errorf("DFS = %v, want %v", dfs, want)
+	}
+	if want := []string{"a", "b", "c", "d", "e", "f", "g"}; !slices.Equal(bfs, want) {
+		t.Errorf("BFS = %v, want %v", bfs, want)
+	}
+}
+
+func TestTreeNil(t *testing.T) {
+	var root *Tree[int]
+	visited := 0
+	root.DFS(func(int) { visited++ })
+	root.BFS(func(int) { visited++ })
+	if visited != 0 {
+		t.Errorf("visited %d nodes of a nil tree", visited)
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+// action is a do/undo closure pair
+type action struct {
+	do, undo func()
+}
+
+// UndoStack records actions as closures so they can be undone & redone ↩️↪️
+type UndoStack struct {
+	done   []action
+	undone []action
+}
+
+// Execute runs do and records it. Any redo history is discarded.
+func (u *UndoStack) Execute(do, undo func()) {
+	do()
+	u.done = append(u.done, action{do: do, undo: undo})
+	u.undone = nil
+}
+
+// Undo reverts the latest action, reporting false if there is nothing to undo.
+func (u *UndoStack) Undo() bool {
+	if len(u.done) == 0 {
+		return false
+	}
+	last := u.done[len(u.done)-1]
+	u.done = u.done[:len(u.done)-1]
+	last.undo()
+	u.undone = append(u.undone, last)
+	return true
+}
+
+// Redo re-applies the latest undone action, reporting false if there is nothing to redo.
+func (u *UndoStack) Redo() bool {
+	if len(u.undone) == 0 {
+		return false
+	}
+	last := u.undone[len(u.undone)-1]
+	u.undone = u.undone[:len(u.undone)-1]
+	last.do()
+	u.done = append(u.done, last)
+	return true
+}
+
+func (u *UndoStack) CanUndo() bool { return len(u.done) > 0 }
+func (u *UndoStack) CanRedo() bool { return len(u.undone) > 0 }
@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+// addTo returns a do/undo pair adding n to *balance
+func addTo(balance *int, n int) (do, undo func()) {
+	return func() { *balance += n }, func() { *balance -= n }
+}
+
+func TestUndoRedo(t *testing.T) {
+	var u UndoStack
+	balance := 0
+
+	u.Execute(addTo(&balance, 10))
+	u.Execute(addTo(&balance, 5))
+	if balance != 15 {
+		t.Fatalf("balance = %d, want 15", balance)
+	}
+
+	if !u.Undo() || balance != 10 {
+		t.Fatalf("after Undo balance = %d, want 10", balance)
+	}
+	if !u.Redo() || balance != 15 {
+		t.Fatalf("after Redo balance = %d, want 15", balance)
+	}
+	u.Undo()
+	u.Undo()
+	if balance != 0 || u.CanUndo() {
+		t.Errorf("balance = %d, CanUndo = %v; want 0, false", balance, u.CanUndo())
+	}
+	if u.Undo() {
+		t.Error("Undo on empty stack should report false")
+	}
+}
+
+func TestExecuteClearsRedo(t *testing.T) {
+	var u UndoStack
+	balance := 0
+
+	u.Execute(addTo(&balance, 10))
+	u.Undo()
+	if !u.CanRedo() {
+		t.Fatal("CanRedo = false after Undo")
+	}
+
+	u.Execute(addTo(&balance, 3))
+	if u.CanRedo() || u.Redo() {
+		t.Error("redo history should be cleared by Execute")
+	}
+	if balance != 3 {
+		t.Errorf("balance = %d, want 3", balance)
+	}
+}
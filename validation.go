@@ -0,0 +1,41 @@
+package main
+
+import "strings"
+
+// FieldError is a single failed check on one field
+type FieldError struct {
+	Field string
+	Msg   string
+}
+
+// ValidationError collects every failed check, so callers see all problems at once ✅❌
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (v *ValidationError) Add(field, msg string) {
+	v.Errors = append(v.Errors, FieldError{Field: field, Msg: msg})
+}
+
+func (v *ValidationError) HasErrors() bool {
+	return len(v.Errors) > 0
+}
+
+// Error renders one line per failed field.
+func (v *ValidationError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("validation failed:")
+	for _, fe := range v.Errors {
+		sb.WriteString("\n  - " + fe.Field + ": " + fe.Msg)
+	}
+	return sb.String()
+}
+
+// Err returns v as an error, or nil when nothing failed
+// (avoids the classic "non-nil interface holding a nil pointer" trap).
+func (v *ValidationError) Err() error {
+	if !v.HasErrors() {
+		return nil
+	}
+	return v
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidationErrorCollectsAll(t *testing.T) {
+	var v ValidationError
+	v.Add("name", "must not be empty")
+	v.Add("age", "must be positive")
+
+	err := v.Err()
+	if err == nil {
+		t.Fatal("Err() = nil with two failures")
+	}
+	want := "validation failed:\n  - name: must not be empty\n  - age: must be positive"
+	if err.Error() != want {
+		t.Errorf("Error() =\n%s\nwant\n%s", err.Error(), want)
+	}
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Errors) != 2 {
+		t.Errorf("errors.As = %v, want the 2 field errors", verr)
+	}
+}
+
+func TestValidationErrorEmpty(t *testing.T) {
+	var v ValidationError
+	if v.HasErrors() {
+		t.Error("HasErrors() = true on empty")
+	}
+	if err := v.Err(); err != nil {
+		t.Errorf("Err() = %v, want untyped nil", err)
+	}
+	if !strings.HasPrefix(v.Error(), "validation failed") {
+		t.Errorf("Error() = %q", v.Error())
+	}
+}
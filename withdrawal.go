@@ -0,0 +1,27 @@
+package main
+
+// withdrawalPolicy - what must stay behind when money is withdrawn.
+// Withdraw enforces it; decideWithdrawal only suggests what fits.
+type withdrawalPolicy struct {
+	MinBalance float64 // balance that must remain in the account
+	Fee        float64 // charged on every withdrawal
+}
+
+// bankWithdrawalPolicy is what GoBank applies today (see NewAccount): no fee, no minimum balance
+var bankWithdrawalPolicy = withdrawalPolicy{}
+
+// maxWithdrawable is the most that can be withdrawn from balance under p (never negative).
+func (p withdrawalPolicy) maxWithdrawable(balance float64) float64 {
+	return max(RoundMoney(balance-p.MinBalance-p.Fee, Down), 0)
+}
+
+// decideWithdrawal reports whether amount can be withdrawn from balance under p.
+// When it can't, suggestion is the largest amount that could be withdrawn instead
+// (0 if nothing can).
+func decideWithdrawal(balance, amount float64, p withdrawalPolicy) (ok bool, suggestion float64) {
+	limit := p.maxWithdrawable(balance)
+	if amount <= limit {
+		return true, amount
+	}
+	return false, limit
+}
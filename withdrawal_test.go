@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDecideWithdrawal(t *testing.T) {
+	strict := withdrawalPolicy{MinBalance: 100, Fee: 2.5}
+	tests := []struct {
+		name           string
+		balance, amt   float64
+		policy         withdrawalPolicy
+		wantOK         bool
+		wantSuggestion float64
+	}{
+		{"enough funds", 1000, 200, bankWithdrawalPolicy, true, 200},
+		{"whole balance", 1000, 1000, bankWithdrawalPolicy, true, 1000},
+		{"too much", 1000, 1500, bankWithdrawalPolicy, false, 1000},
+		{"min balance and fee", 1000, 950, strict, false, 897.5},
+		{"exactly the limit", 1000, 897.5, strict, true, 897.5},
+		{"nothing withdrawable", 50, 10, strict, false, 0},
+		{"suggestion rounds down", 10.019, 20, bankWithdrawalPolicy, false, 10.01},
+	}
+	for _, tt := range tests {
+		ok, suggestion := decideWithdrawal(tt.balance, tt.amt, tt.policy)
+		if ok != tt.wantOK || suggestion != tt.wantSuggestion {
+			t.Errorf("%s: decideWithdrawal = %v, %v; want %v, %v", tt.name, ok, suggestion, tt.wantOK, tt.wantSuggestion)
+		}
+	}
+}
+
+func TestWithdrawEnforcesPolicy(t *testing.T) {
+	ctx := context.Background()
+	acc := NewAccount(1000, nil)
+	acc.SetWithdrawalPolicy(withdrawalPolicy{MinBalance: 100, Fee: 2.5})
+
+	if err := Withdraw(ctx, acc, 97.5); err != nil {
+		t.Fatalf("Withdraw: %v", err)
+	}
+	if got := acc.Balance(); got != 900 {
+		t.Errorf("balance = %v, want 900 (97.50 + 2.50 fee)", got)
+	}
+	if err := Withdraw(ctx, acc, 798); !errors.Is(err, ErrInsufficientFunds) {
+		t.Errorf("Withdraw below the minimum balance = %v, want ErrInsufficientFunds", err)
+	}
+	if err := Withdraw(ctx, acc, 797.5); err != nil {
+		t.Errorf("Withdraw down to exactly the minimum: %v", err)
+	}
+	if got := acc.Balance(); got != 100 {
+		t.Errorf("balance = %v, want 100", got)
+	}
+}
+
+func TestWithdrawSuggestionIsAccepted(t *testing.T) {
+	// whatever decideWithdrawal suggests, Withdraw must accept
+	for _, p := range []withdrawalPolicy{{}, {MinBalance: 100}, {MinBalance: 25.5, Fee: 1.99}} {
+		for _, balance := range []float64{128.01, 1000.1, 1234.57} {
+			acc := NewAccount(balance, nil)
+			acc.SetWithdrawalPolicy(p)
+			ok, suggestion := decideWithdrawal(balance, balance*2, p)
+			if ok || suggestion <= 0 {
+				t.Fatalf("decideWithdrawal(%v, %+v) = %v, %v", balance, p, ok, suggestion)
+			}
+			if err := Withdraw(context.Background(), acc, suggestion); err != nil {
+				t.Errorf("balance %v, policy %+v: suggested %v rejected: %v", balance, p, suggestion, err)
+			}
+			if got := acc.Balance(); got < p.MinBalance {
+				t.Errorf("balance %v, policy %+v: left %v, below the minimum", balance, p, got)
+			}
+		}
+	}
+}
+
+func TestDepositIgnoresPolicy(t *testing.T) {
+	acc := NewAccount(10, nil)
+	acc.SetWithdrawalPolicy(withdrawalPolicy{MinBalance: 100, Fee: 2.5})
+	if err := Deposit(context.Background(), acc, 5); err != nil {
+		t.Fatalf("Deposit below the minimum balance: %v", err)
+	}
+	if got := acc.Balance(); got != 15 {
+		t.Errorf("balance = %v, want 15 (no fee on deposits)", got)
+	}
+}